@@ -0,0 +1,62 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+
+	"go.opencensus.io/trace"
+)
+
+// ErrNoAssets is returned if no assets were returned
+var ErrNoAssets = errors.New("contentful: no assets returned")
+
+// GetAssets from Contentful. Unlike with entries, the assets can be filtered by their fields without
+// setting the content type, e.g.
+//   contentful.Parameters().
+//     ByMimeTypeGroup("image").
+//     ByFieldValue("file.contentType", "image/png")
+//
+// Will return an error if zero assets were returned
+func (cms *Contentful) GetAssets(ctx context.Context, parameters SearchParameters) ([]Asset, error) {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.GetAssets")
+	defer span.End()
+
+	var assets []Asset
+
+	response, err := cms.searchAssets(ctx, parameters)
+	if err != nil {
+		addSpanError(span, trace.StatusCodeUnknown, err)
+		return assets, err
+	}
+
+	if response.Total == 0 || len(response.Items) == 0 {
+		addSpanError(span, trace.StatusCodeNotFound, ErrNoAssets)
+		return assets, ErrNoAssets
+	}
+
+	err = cms.parse(ctx, span, parameters, response, false, &assets)
+	return assets, err
+}
+
+// GetAssetByID returns the asset with the given ID in the default locale.
+// Use GetAssets with ByID and ByLocale for other locales
+func (cms *Contentful) GetAssetByID(ctx context.Context, id string) (Asset, error) {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.GetAssetByID")
+	defer span.End()
+
+	var asset Asset
+
+	response, err := cms.searchAssets(ctx, Parameters().ByID(id))
+	if err != nil {
+		addSpanError(span, trace.StatusCodeUnknown, err)
+		return asset, err
+	}
+
+	if response.Total == 0 || len(response.Items) == 0 {
+		addSpanError(span, trace.StatusCodeNotFound, ErrNoAssets)
+		return asset, ErrNoAssets
+	}
+
+	err = cms.parse(ctx, span, Parameters(), response, true, &asset)
+	return asset, err
+}
@@ -0,0 +1,66 @@
+package contentful
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentful_GetAssets(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query  string
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/spaces/spaceID/assets", r.URL.Path)
+			query = r.URL.RawQuery
+			if r.URL.Query().Get("mimetype_group") == "video" {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"total": 0, "items": []}`))
+				return
+			}
+
+			bytes, err := ioutil.ReadFile("testdata/assets.json")
+			assert.NoError(t, err)
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(bytes)
+			assert.NoError(t, err)
+		}))
+		cms = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		ctx = context.Background()
+	)
+	defer server.Close()
+
+	t.Run("GetAssets", func(t *testing.T) {
+		assets, err := cms.GetAssets(ctx, Parameters().ByMimeTypeGroup("image").ByFieldValue("file.contentType", "image/png"))
+		assert.NoError(t, err)
+		assert.Equal(t, "fields.file.contentType=image%2Fpng&mimetype_group=image", query)
+		assert.Equal(t, 2, len(assets))
+		assert.Equal(t, "2BNT5Xj0CsgUOSMkKysYKq", assets[0].ID)
+		assert.Equal(t, 2, assets[0].Revision)
+		assert.Equal(t, "en-US", assets[0].Locale)
+		assert.Equal(t, "Green", assets[0].Title)
+		assert.Equal(t, "green.png", assets[0].File.FileName)
+		assert.Equal(t, 1508, assets[0].File.Details.Size)
+		assert.Equal(t, 100, assets[0].File.Details.Image.Width)
+		assert.Equal(t, 100, assets[0].File.Details.Image.Height)
+		assert.Equal(t, "Orange", assets[1].Title)
+	})
+
+	t.Run("GetAssetByID", func(t *testing.T) {
+		asset, err := cms.GetAssetByID(ctx, "2BNT5Xj0CsgUOSMkKysYKq")
+		assert.NoError(t, err)
+		assert.Equal(t, "sys.id=2BNT5Xj0CsgUOSMkKysYKq", query)
+		assert.Equal(t, "Green", asset.Title)
+		assert.Equal(t, "image/png", asset.File.ContentType)
+	})
+
+	t.Run("Returns ErrNoAssets if there are no assets", func(t *testing.T) {
+		_, err := cms.GetAssets(ctx, Parameters().ByMimeTypeGroup("video"))
+		assert.Equal(t, ErrNoAssets, err)
+	})
+}
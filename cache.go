@@ -0,0 +1,166 @@
+package contentful
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheTTL        = 5 * time.Minute
+	defaultCacheMaxEntries = 1000
+)
+
+// CacheOptions configures the response cache, see WithCache
+type CacheOptions struct {
+	// TTL is how long a response is cached. Defaults to 5 minutes
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached responses. Defaults to 1000
+	MaxEntries int
+	// MaxBytes is the maximum total size of the cached responses. Defaults to no limit
+	MaxBytes int
+}
+
+// CacheStats has the counters of the response cache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Entries is the number of cached responses
+	Entries int
+	// Bytes is the total size of the cached responses
+	Bytes int
+}
+
+// WithCache caches the responses of the entry and asset searches in memory. The responses are cached before
+// flattening, so the same response can be decoded into different types. The cache key consists of the search
+// parameters, the environment and whether the preview API is used. When the cache is full, the least recently used
+// responses are evicted. Disabled by default
+func WithCache(options CacheOptions) Option {
+	return func(cms *Contentful) {
+		cms.cache = newCache(options)
+	}
+}
+
+// CacheStats returns the counters of the response cache. Returns zero values if the cache is not enabled
+func (cms *Contentful) CacheStats() CacheStats {
+	if cms.cache == nil {
+		return CacheStats{}
+	}
+	return cms.cache.statistics()
+}
+
+// ClearCache removes all the responses from the cache
+func (cms *Contentful) ClearCache() {
+	if cms.cache != nil {
+		cms.cache.clear()
+	}
+}
+
+// cache is a LRU cache for the raw responses
+type cache struct {
+	mutex   sync.Mutex
+	options CacheOptions
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   CacheStats
+}
+
+type cacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func newCache(options CacheOptions) *cache {
+	if options.TTL <= 0 {
+		options.TTL = defaultCacheTTL
+	}
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = defaultCacheMaxEntries
+	}
+
+	return &cache{
+		options: options,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (c *cache) get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(element)
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.lru.MoveToFront(element)
+	c.stats.Hits++
+	return entry.value, true
+}
+
+func (c *cache) set(key string, value []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	if c.options.MaxBytes > 0 && len(value) > c.options.MaxBytes {
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{
+		key:     key,
+		value:   value,
+		expires: time.Now().Add(c.options.TTL),
+	})
+	c.stats.Bytes += len(value)
+
+	for len(c.entries) > c.options.MaxEntries || (c.options.MaxBytes > 0 && c.stats.Bytes > c.options.MaxBytes) {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *cache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.stats.Bytes -= len(entry.value)
+}
+
+func (c *cache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.stats.Bytes = 0
+}
+
+func (c *cache) statistics() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	return stats
+}
@@ -0,0 +1,137 @@
+package contentful
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Defaults", func(t *testing.T) {
+		c := newCache(CacheOptions{})
+		assert.Equal(t, defaultCacheTTL, c.options.TTL)
+		assert.Equal(t, defaultCacheMaxEntries, c.options.MaxEntries)
+		assert.Equal(t, 0, c.options.MaxBytes)
+	})
+
+	t.Run("Hits and misses", func(t *testing.T) {
+		c := newCache(CacheOptions{})
+		_, ok := c.get("key")
+		assert.False(t, ok)
+
+		c.set("key", []byte("value"))
+		value, ok := c.get("key")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("value"), value)
+
+		c.set("key", []byte("new"))
+		value, _ = c.get("key")
+		assert.Equal(t, []byte("new"), value)
+		assert.Equal(t, CacheStats{Hits: 2, Misses: 1, Entries: 1, Bytes: 3}, c.statistics())
+
+		c.clear()
+		_, ok = c.get("key")
+		assert.False(t, ok)
+		assert.Equal(t, CacheStats{Hits: 2, Misses: 2}, c.statistics())
+	})
+
+	t.Run("Expires", func(t *testing.T) {
+		c := newCache(CacheOptions{TTL: time.Millisecond})
+		c.set("key", []byte("value"))
+		time.Sleep(5 * time.Millisecond)
+		_, ok := c.get("key")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.statistics().Entries)
+	})
+
+	t.Run("Evicts the least recently used entries", func(t *testing.T) {
+		c := newCache(CacheOptions{MaxEntries: 2})
+		c.set("a", []byte("a"))
+		c.set("b", []byte("b"))
+		c.get("a")
+		c.set("c", []byte("c"))
+
+		_, ok := c.get("b")
+		assert.False(t, ok)
+		_, ok = c.get("a")
+		assert.True(t, ok)
+		_, ok = c.get("c")
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), c.statistics().Evictions)
+	})
+
+	t.Run("Evicts when the size is exceeded", func(t *testing.T) {
+		c := newCache(CacheOptions{MaxBytes: 5})
+		c.set("a", []byte("aaa"))
+		c.set("b", []byte("bbb"))
+		_, ok := c.get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 3, c.statistics().Bytes)
+
+		c.set("c", []byte("too large"))
+		_, ok = c.get("c")
+		assert.False(t, ok)
+		_, ok = c.get("b")
+		assert.True(t, ok)
+	})
+}
+
+func TestContentful_cache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests int32
+		server   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			bytes, err := ioutil.ReadFile("testdata/prod_main_page.json")
+			assert.NoError(t, err)
+			_, err = w.Write(bytes)
+			assert.NoError(t, err)
+		}))
+		cms = NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithCache(CacheOptions{}))
+		ctx = context.Background()
+	)
+	defer server.Close()
+
+	type title struct {
+		Title string `json:"title"`
+	}
+	type page struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Information
+	}
+
+	var (
+		result1 title
+		result2 page
+	)
+	err := cms.GetOne(ctx, Parameters().ByContentType("page").ByLocale("en-US"), &result1)
+	assert.NoError(t, err)
+	err = cms.GetOne(ctx, Parameters().ByLocale("en-US").ByContentType("page"), &result2)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	assert.Equal(t, result1.Title, result2.Title)
+	assert.NotEqual(t, "", result2.ID)
+
+	err = cms.GetOne(ContextWithEnvironment(ctx, "staging"), Parameters().ByContentType("page").ByLocale("en-US"), &result2)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	stats := cms.CacheStats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, 2, stats.Entries)
+
+	cms.ClearCache()
+	assert.Equal(t, 0, cms.CacheStats().Entries)
+	assert.Equal(t, CacheStats{}, NewWithOptions("token", "spaceID").CacheStats())
+}
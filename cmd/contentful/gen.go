@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	contentful "github.com/janivihervas/contentful-go/v2"
+)
+
+// commonInitialisms are written in upper case in the generated identifiers, like golint suggests
+var commonInitialisms = map[string]bool{
+	"API": true, "CSS": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "JSON": true,
+	"SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// reservedFieldNames can't be used as field names of the generated structs
+var reservedFieldNames = map[string]bool{
+	"Information":      true,
+	"EntryInformation": true,
+}
+
+// reservedTypeNames can't be used as names of the generated structs
+var reservedTypeNames = map[string]bool{
+	"Entry":     true,
+	"EntryLink": true,
+}
+
+// runGen runs the gen subcommand, which generates Go structs from the content types
+func runGen(args []string) error {
+	var (
+		flags       = flag.NewFlagSet("gen", flag.ExitOnError)
+		file        = flags.String("file", "", "Read the content types from a json file instead of the API")
+		packageName = flags.String("package", "models", "Package name of the generated code")
+		output      = flags.String("output", "", "Write the generated code to a file instead of stdout")
+	)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: contentful [-token token -space space] gen [flags]")
+		flags.PrintDefaults()
+	}
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	var contentTypes []contentful.ContentType
+	if *file != "" {
+		contentTypes, err = readContentTypes(*file)
+	} else {
+		if token == "" || spaceID == "" {
+			return errors.New("token and space are required when not reading the content types from a file")
+		}
+		cms := contentful.NewWithOptions(token, spaceID, contentful.WithPreview(preview), contentful.WithEnvironment(environment))
+		contentTypes, err = cms.GetContentTypes(context.Background())
+	}
+	if err != nil {
+		return err
+	}
+
+	code, err := generate(contentTypes, *packageName)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return ioutil.WriteFile(*output, code, 0644)
+}
+
+// readContentTypes reads either the response of the content types endpoint or a json array of content types
+func readContentTypes(file string) ([]contentful.ContentType, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var contentTypes []contentful.ContentType
+	if len(bytes.TrimSpace(data)) > 0 && bytes.TrimSpace(data)[0] == '[' {
+		err = json.Unmarshal(data, &contentTypes)
+		return contentTypes, err
+	}
+
+	response := struct {
+		Items []contentful.ContentType `json:"items"`
+	}{}
+	err = json.Unmarshal(data, &response)
+	return response.Items, err
+}
+
+// generate returns formatted Go code with a struct for each content type
+func generate(contentTypes []contentful.ContentType, packageName string) ([]byte, error) {
+	contentTypes = append([]contentful.ContentType(nil), contentTypes...)
+	sort.Slice(contentTypes, func(i, j int) bool {
+		return contentTypes[i].ID < contentTypes[j].ID
+	})
+
+	typeNames := make(map[string]string, len(contentTypes))
+	typeIDs := make(map[string]string, len(contentTypes))
+	for _, contentType := range contentTypes {
+		name := goName(contentType.ID)
+		if reservedTypeNames[name] {
+			name += "Type"
+		}
+		if id, ok := typeIDs[name]; ok {
+			return nil, fmt.Errorf("content types %q and %q have the same Go name %s", id, contentType.ID, name)
+		}
+		typeNames[contentType.ID] = name
+		typeIDs[name] = contentType.ID
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by contentful gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", packageName)
+	fmt.Fprintf(buf, "import (\n\"encoding/json\"\n\ncontentful %q\n)\n\n", "github.com/janivihervas/contentful-go/v2")
+
+	fmt.Fprintf(buf, "// Entry is implemented by all the generated content types\n")
+	fmt.Fprintf(buf, "type Entry interface {\nEntryInformation() contentful.Information\n}\n\n")
+
+	for _, contentType := range contentTypes {
+		name := typeNames[contentType.ID]
+		fmt.Fprintf(buf, "// %s is the content type %q", name, contentType.ID)
+		if contentType.Description != "" {
+			fmt.Fprintf(buf, ": %s", contentType.Description)
+		}
+		fmt.Fprintf(buf, "\ntype %s struct {\n", name)
+
+		fieldIDs := make(map[string]string, len(contentType.Fields))
+		for _, field := range contentType.Fields {
+			if field.Omitted || field.Disabled {
+				continue
+			}
+			fieldName := goName(field.ID)
+			if reservedFieldNames[fieldName] {
+				fieldName += "Field"
+			}
+			if id, ok := fieldIDs[fieldName]; ok {
+				return nil, fmt.Errorf("fields %q and %q of content type %q have the same Go name %s", id, field.ID, contentType.ID, fieldName)
+			}
+			fieldIDs[fieldName] = field.ID
+			fmt.Fprintf(buf, "%s %s `json:%q`\n", fieldName, goType(field, typeNames), field.ID)
+		}
+
+		fmt.Fprintf(buf, "contentful.Information\n}\n\n")
+		fmt.Fprintf(buf, "// EntryInformation implements Entry\n")
+		fmt.Fprintf(buf, "func (e %s) EntryInformation() contentful.Information {\nreturn e.Information\n}\n\n", name)
+	}
+
+	fmt.Fprintf(buf, "// EntryLink holds an entry of any of the generated content types. It is used for the links\n")
+	fmt.Fprintf(buf, "// which can refer to more than one content type. Entry is nil if the content type is unknown\n")
+	fmt.Fprintf(buf, "type EntryLink struct {\nEntry Entry\n}\n\n")
+	fmt.Fprintf(buf, "// UnmarshalJSON unmarshals the entry into the struct of its content type\n")
+	fmt.Fprintf(buf, "func (l *EntryLink) UnmarshalJSON(data []byte) error {\n")
+	fmt.Fprintf(buf, "var information contentful.Information\nif err := json.Unmarshal(data, &information); err != nil {\nreturn err\n}\n\n")
+	fmt.Fprintf(buf, "switch information.ContentType {\n")
+	for _, contentType := range contentTypes {
+		fmt.Fprintf(buf, "case %q:\nentry := &%s{}\nl.Entry = entry\nreturn json.Unmarshal(data, entry)\n", contentType.ID, typeNames[contentType.ID])
+	}
+	fmt.Fprintf(buf, "default:\nl.Entry = nil\nreturn nil\n}\n}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// goType returns the Go type of the field
+func goType(field contentful.Field, typeNames map[string]string) string {
+	if field.Type == contentful.FieldArray && field.Items != nil {
+		item := contentful.Field{
+			Type:        field.Items.Type,
+			LinkType:    field.Items.LinkType,
+			Validations: field.Items.Validations,
+		}
+		elem := goType(item, typeNames)
+		return "[]" + strings.TrimPrefix(elem, "*")
+	}
+
+	switch field.Type {
+	case contentful.FieldSymbol, contentful.FieldText, contentful.FieldDate:
+		return "string"
+	case contentful.FieldInteger:
+		return "int"
+	case contentful.FieldNumber:
+		return "float64"
+	case contentful.FieldBoolean:
+		return "bool"
+	case contentful.FieldLocation:
+		return "contentful.Location"
+	case contentful.FieldLink:
+		if field.LinkType == "Asset" {
+			return "contentful.Asset"
+		}
+		linkContentTypes := field.LinkContentTypes()
+		if len(linkContentTypes) == 1 {
+			if name, ok := typeNames[linkContentTypes[0]]; ok {
+				return "*" + name
+			}
+		}
+		return "*EntryLink"
+	default:
+		// Object fields can hold any JSON value, not only objects
+		return "interface{}"
+	}
+}
+
+// goName converts an ID like "blogPost", "blog-post" or "blog_post" to an exported Go identifier "BlogPost"
+func goName(id string) string {
+	var (
+		words []string
+		word  []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+
+	runes := []rune(id)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	name := ""
+	for _, w := range words {
+		if upper := strings.ToUpper(w); commonInitialisms[upper] {
+			name += upper
+			continue
+		}
+		r := []rune(w)
+		name += string(unicode.ToUpper(r[0])) + string(r[1:])
+	}
+
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "F" + name
+	}
+	return name
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"go/parser"
+	gotoken "go/token"
+	"strings"
+	"testing"
+
+	contentful "github.com/janivihervas/contentful-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	contentTypes, err := readContentTypes("../../testdata/content_types.json")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(contentTypes))
+
+	code, err := generate(contentTypes, "models")
+	assert.NoError(t, err)
+
+	_, err = parser.ParseFile(gotoken.NewFileSet(), "models.go", code, parser.AllErrors)
+	assert.NoError(t, err)
+
+	// Ignore the alignment of the struct fields
+	generated := strings.Join(strings.Fields(string(code)), " ")
+	expected := []string{
+		"package models",
+		"type Page struct {",
+		"Title     string           `json:\"title\"`",
+		"Order     int              `json:\"order\"`",
+		"Banner    contentful.Asset `json:\"banner\"`",
+		"Author    *Person          `json:\"author\"`",
+		"SubPages  []Page           `json:\"subPages\"`",
+		"Blocks    []EntryLink      `json:\"blocks\"`",
+		"Tags      []string         `json:\"tags\"`",
+		"Location  contentful.Location `json:\"location\"`",
+		"Rating   float64                `json:\"rating\"`",
+		"Anything *EntryLink             `json:\"anything\"`",
+		"func (e Person) EntryInformation() contentful.Information {",
+		"case \"person\":",
+	}
+	for _, e := range expected {
+		assert.Contains(t, generated, strings.Join(strings.Fields(e), " "))
+	}
+	assert.NotContains(t, string(code), "Hidden")
+}
+
+func TestGenerate_reservedNames(t *testing.T) {
+	code, err := generate([]contentful.ContentType{
+		{
+			ID: "entry",
+			Fields: []contentful.Field{
+				{ID: "information", Type: contentful.FieldSymbol},
+				{ID: "id", Type: contentful.FieldSymbol},
+			},
+		},
+	}, "models")
+	assert.NoError(t, err)
+	assert.Contains(t, string(code), "type EntryType struct {")
+	assert.Contains(t, string(code), "InformationField string `json:\"information\"`")
+	assert.Contains(t, string(code), "ID               string `json:\"id\"`")
+}
+
+func TestGenerate_nameClash(t *testing.T) {
+	_, err := generate([]contentful.ContentType{{ID: "blog-post"}, {ID: "blogPost"}}, "models")
+	assert.EqualError(t, err, `content types "blog-post" and "blogPost" have the same Go name BlogPost`)
+
+	_, err = generate([]contentful.ContentType{{ID: "entry"}, {ID: "entryType"}}, "models")
+	assert.Error(t, err)
+
+	_, err = generate([]contentful.ContentType{
+		{
+			ID: "page",
+			Fields: []contentful.Field{
+				{ID: "seo_url", Type: contentful.FieldSymbol},
+				{ID: "seoUrl", Type: contentful.FieldSymbol},
+			},
+		},
+	}, "models")
+	assert.EqualError(t, err, `fields "seo_url" and "seoUrl" of content type "page" have the same Go name SeoURL`)
+}
+
+func TestGenerate_object(t *testing.T) {
+	code, err := generate([]contentful.ContentType{
+		{
+			ID:     "page",
+			Fields: []contentful.Field{{ID: "metadata", Type: contentful.FieldObject}},
+		},
+	}, "models")
+	assert.NoError(t, err)
+	assert.Contains(t, string(code), "Metadata interface{} `json:\"metadata\"`")
+}
+
+func TestGoName(t *testing.T) {
+	cases := map[string]string{
+		"page":         "Page",
+		"blogPost":     "BlogPost",
+		"blog-post":    "BlogPost",
+		"blog_post":    "BlogPost",
+		"id":           "ID",
+		"seoUrl":       "SeoURL",
+		"3dModel":      "F3dModel",
+		"HTMLSnippet":  "HTMLSnippet",
+		"landing page": "LandingPage",
+		"":             "F",
+	}
+
+	for id, expected := range cases {
+		assert.Equal(t, expected, goName(id), id)
+	}
+}
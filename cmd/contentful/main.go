@@ -13,20 +13,37 @@ import (
 )
 
 var (
-	token   string
-	spaceID string
-	preview bool
+	token       string
+	spaceID     string
+	environment string
+	preview     bool
 )
 
 func init() {
 	flag.StringVar(&token, "token", "", "Contentful access token")
 	flag.StringVar(&spaceID, "space", "", "Contentful space id")
+	flag.StringVar(&environment, "environment", "", "Contentful environment or environment alias, defaults to master")
 	flag.BoolVar(&preview, "preview", false, "Whether to use the preview API or not")
 }
 
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage:")
+		fmt.Fprintln(flag.CommandLine.Output(), "  contentful -token token -space space [flags] key=value...")
+		fmt.Fprintln(flag.CommandLine.Output(), "  contentful [-token token -space space] gen [gen flags]")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	if flag.Arg(0) == "gen" {
+		err := runGen(flag.Args()[1:])
+		if err != nil {
+			fmt.Println("Could not generate the structs:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if token == "" || spaceID == "" {
 		flag.Usage()
 		os.Exit(1)
@@ -47,7 +64,7 @@ func main() {
 		parameters.Add(parts[0], parts[1])
 	}
 
-	cms := contentful.New(token, spaceID, preview)
+	cms := contentful.NewWithOptions(token, spaceID, contentful.WithPreview(preview), contentful.WithEnvironment(environment))
 
 	result := make([]map[string]interface{}, 1)
 	err := cms.GetMany(context.Background(), contentful.SearchParameters{Values: parameters}, &result)
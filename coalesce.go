@@ -0,0 +1,166 @@
+package contentful
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup coalesces identical concurrent requests, so that they share one request and one parsed response
+type flightGroup struct {
+	mutex   sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a request shared by the callers waiting for it
+type flight struct {
+	done     chan struct{}
+	response searchResults
+	err      error
+	waiters  int
+	cancel   context.CancelFunc
+	// deadlines of the waiters, which have one, and the number of waiters without a deadline
+	deadlines []time.Time
+	unbounded int
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do calls fn once for the concurrent callers with the same key and returns its results to all of them.
+// fn is called with a context, which has the values of the first caller's context, but is cancelled only when all
+// the callers have returned because of their contexts. The deadline of the context is the latest deadline of the
+// waiting callers, or none if a caller has no deadline. Returns true if the call was shared with an earlier caller.
+// A nil group calls fn directly
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (searchResults, error)) (searchResults, bool, error) {
+	if g == nil {
+		response, err := fn(ctx)
+		return response, false, err
+	}
+
+	g.mutex.Lock()
+	f, shared := g.flights[key]
+	if !shared {
+		f = &flight{done: make(chan struct{})}
+		flightCtx, cancel := context.WithCancel(detachedContext{parent: ctx, deadline: func() (time.Time, bool) {
+			g.mutex.Lock()
+			defer g.mutex.Unlock()
+			return f.deadline()
+		}})
+		f.cancel = cancel
+		g.flights[key] = f
+
+		go func() {
+			f.response, f.err = fn(flightCtx)
+
+			g.mutex.Lock()
+			g.forget(key, f)
+			g.mutex.Unlock()
+			cancel()
+			close(f.done)
+		}()
+	}
+	f.join(ctx)
+	g.mutex.Unlock()
+
+	select {
+	case <-f.done:
+		return f.response.clone(), shared, f.err
+	case <-ctx.Done():
+		g.mutex.Lock()
+		f.leave(ctx)
+		if f.waiters == 0 {
+			// Nobody is waiting for the response anymore
+			f.cancel()
+			g.forget(key, f)
+		}
+		g.mutex.Unlock()
+		return searchResults{}, shared, ctx.Err()
+	}
+}
+
+// join adds the caller with the given context to the waiters. Must be called with the mutex of the group locked
+func (f *flight) join(ctx context.Context) {
+	f.waiters++
+	if deadline, ok := ctx.Deadline(); ok {
+		f.deadlines = append(f.deadlines, deadline)
+	} else {
+		f.unbounded++
+	}
+}
+
+// leave removes the caller with the given context from the waiters. Must be called with the mutex of the group locked
+func (f *flight) leave(ctx context.Context) {
+	f.waiters--
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		f.unbounded--
+		return
+	}
+	for i := range f.deadlines {
+		if f.deadlines[i].Equal(deadline) {
+			f.deadlines = append(f.deadlines[:i], f.deadlines[i+1:]...)
+			return
+		}
+	}
+}
+
+// deadline returns the latest deadline of the waiters, or false if a waiter has no deadline.
+// Must be called with the mutex of the group locked
+func (f *flight) deadline() (time.Time, bool) {
+	if f.unbounded > 0 || len(f.deadlines) == 0 {
+		return time.Time{}, false
+	}
+
+	latest := f.deadlines[0]
+	for _, deadline := range f.deadlines[1:] {
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+	return latest, true
+}
+
+// forget removes the flight, so that the following callers start a new one. Must be called with the mutex locked
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
+
+// clone returns a copy of the results, which can be appended to without affecting the other callers.
+// The fields of the items are shared, as they are only read when flattening
+func (r searchResults) clone() searchResults {
+	r.Items = append([]item(nil), r.Items...)
+	r.Includes.Entry = append([]item(nil), r.Includes.Entry...)
+	r.Includes.Asset = append([]item(nil), r.Includes.Asset...)
+	r.Errors = append([]responseError(nil), r.Errors...)
+	return r
+}
+
+// detachedContext has the values of the parent context, e.g. the environment and the trace span,
+// but not its cancellation. The deadline is given by the deadline function, so that it can change with the waiters
+type detachedContext struct {
+	parent   context.Context
+	deadline func() (time.Time, bool)
+}
+
+func (c detachedContext) Deadline() (time.Time, bool) {
+	if c.deadline == nil {
+		return time.Time{}, false
+	}
+	return c.deadline()
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,223 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForWaiters waits until the flight of the key has the given number of waiters
+func waitForWaiters(t *testing.T, g *flightGroup, key string, waiters int) {
+	for i := 0; i < 1000; i++ {
+		g.mutex.Lock()
+		f, ok := g.flights[key]
+		done := ok && f.waiters == waiters
+		g.mutex.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("flight %s didn't get %d waiters", key, waiters)
+}
+
+func TestFlightGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nil group calls directly", func(t *testing.T) {
+		var g *flightGroup
+		response, shared, err := g.do(context.Background(), "key", func(ctx context.Context) (searchResults, error) {
+			return searchResults{Total: 1}, nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, shared)
+		assert.Equal(t, 1, response.Total)
+	})
+
+	t.Run("Concurrent calls share the results", func(t *testing.T) {
+		var (
+			g       = newFlightGroup()
+			calls   int32
+			release = make(chan struct{})
+			wg      sync.WaitGroup
+			sharedN int32
+		)
+		fn := func(ctx context.Context) (searchResults, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return searchResults{Total: 1, Items: []item{{}}}, errors.New("error")
+		}
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				response, shared, err := g.do(context.Background(), "key", fn)
+				assert.EqualError(t, err, "error")
+				assert.Equal(t, 1, response.Total)
+				if shared {
+					atomic.AddInt32(&sharedN, 1)
+				}
+			}()
+		}
+		waitForWaiters(t, g, "key", 10)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Equal(t, int32(9), atomic.LoadInt32(&sharedN))
+		assert.Empty(t, g.flights)
+	})
+
+	t.Run("Cancelling the first caller doesn't cancel the call", func(t *testing.T) {
+		var (
+			g           = newFlightGroup()
+			release     = make(chan struct{})
+			ctx, cancel = context.WithCancel(context.WithValue(context.Background(), environmentKey{}, "staging"))
+			result      = make(chan error)
+		)
+		fn := func(ctx context.Context) (searchResults, error) {
+			assert.Equal(t, "staging", ctx.Value(environmentKey{}))
+			<-release
+			return searchResults{Total: 1}, ctx.Err()
+		}
+
+		go func() {
+			_, _, err := g.do(ctx, "key", fn)
+			result <- err
+		}()
+		waitForWaiters(t, g, "key", 1)
+		go func() {
+			response, _, err := g.do(context.Background(), "key", fn)
+			assert.Equal(t, 1, response.Total)
+			result <- err
+		}()
+		waitForWaiters(t, g, "key", 2)
+
+		cancel()
+		assert.Equal(t, context.Canceled, <-result)
+		close(release)
+		assert.NoError(t, <-result)
+	})
+
+	t.Run("Cancelling all the callers cancels the call", func(t *testing.T) {
+		var (
+			g           = newFlightGroup()
+			ctx, cancel = context.WithCancel(context.Background())
+			cancelled   = make(chan struct{})
+		)
+
+		go func() {
+			_, _, err := g.do(ctx, "key", func(ctx context.Context) (searchResults, error) {
+				<-ctx.Done()
+				close(cancelled)
+				return searchResults{}, ctx.Err()
+			})
+			assert.Equal(t, context.Canceled, err)
+		}()
+		waitForWaiters(t, g, "key", 1)
+		cancel()
+		<-cancelled
+
+		// The following callers start a new call
+		response, shared, err := g.do(context.Background(), "key", func(ctx context.Context) (searchResults, error) {
+			return searchResults{Total: 2}, nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, shared)
+		assert.Equal(t, 2, response.Total)
+	})
+}
+
+func TestFlight_deadline(t *testing.T) {
+	var (
+		f     flight
+		early = time.Now().Add(time.Second)
+		late  = time.Now().Add(time.Minute)
+	)
+	earlyCtx, cancelEarly := context.WithDeadline(context.Background(), early)
+	defer cancelEarly()
+	lateCtx, cancelLate := context.WithDeadline(context.Background(), late)
+	defer cancelLate()
+
+	_, ok := f.deadline()
+	assert.False(t, ok)
+
+	f.join(earlyCtx)
+	deadline, ok := f.deadline()
+	assert.True(t, ok)
+	assert.Equal(t, early, deadline)
+
+	f.join(lateCtx)
+	deadline, _ = f.deadline()
+	assert.Equal(t, late, deadline)
+
+	// A caller without a deadline can wait for the response for as long as it takes
+	f.join(context.Background())
+	_, ok = f.deadline()
+	assert.False(t, ok)
+
+	f.leave(context.Background())
+	f.leave(lateCtx)
+	deadline, _ = f.deadline()
+	assert.Equal(t, early, deadline)
+	assert.Equal(t, 1, f.waiters)
+}
+
+func TestSearchResults_clone(t *testing.T) {
+	original := searchResults{
+		Items:    make([]item, 1, 10),
+		Includes: includes{Entry: make([]item, 1, 10)},
+	}
+	clone := original.clone()
+	clone.Items = append(clone.Items, item{Sys: itemInfo{ID: "clone"}})
+	clone.Includes.Entry = append(clone.Includes.Entry, item{Sys: itemInfo{ID: "clone"}})
+
+	assert.Equal(t, "", original.Items[:2][1].Sys.ID)
+	assert.Equal(t, "", original.Includes.Entry[:2][1].Sys.ID)
+}
+
+func TestContentful_coalesce(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests int32
+		release  = make(chan struct{})
+		server   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			<-release
+			bytes, err := ioutil.ReadFile("testdata/prod_all_pages.json")
+			assert.NoError(t, err)
+			_, err = w.Write(bytes)
+			assert.NoError(t, err)
+		}))
+		cms    = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		params = Parameters().ByContentType("page")
+		wg     sync.WaitGroup
+	)
+	defer server.Close()
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var pages []map[string]interface{}
+			err := cms.GetMany(context.Background(), params, &pages)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(pages))
+		}()
+	}
+	waitForWaiters(t, cms.flights, "false "+server.URL+"/spaces/spaceID/entries?content_type=page&include=10", 10)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,210 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// FieldType is the type of a content type field
+type FieldType string
+
+// Field types of Contentful
+const (
+	FieldSymbol   FieldType = "Symbol"
+	FieldText     FieldType = "Text"
+	FieldRichText FieldType = "RichText"
+	FieldInteger  FieldType = "Integer"
+	FieldNumber   FieldType = "Number"
+	FieldDate     FieldType = "Date"
+	FieldLocation FieldType = "Location"
+	FieldBoolean  FieldType = "Boolean"
+	FieldLink     FieldType = "Link"
+	FieldArray    FieldType = "Array"
+	FieldObject   FieldType = "Object"
+)
+
+// ContentType is the definition of a content model in Contentful. It can be unmarshaled from
+// and is marshaled to the same json as returned by the Content Delivery API
+type ContentType struct {
+	ID           string
+	Name         string
+	Description  string
+	DisplayField string
+	Revision     int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Fields       []Field
+}
+
+// Field of a content type
+type Field struct {
+	ID   string    `json:"id"`
+	Name string    `json:"name"`
+	Type FieldType `json:"type"`
+	// LinkType is "Entry" or "Asset" for fields of type FieldLink
+	LinkType string `json:"linkType,omitempty"`
+	// Items are set for fields of type FieldArray
+	Items       *FieldItems  `json:"items,omitempty"`
+	Localized   bool         `json:"localized"`
+	Required    bool         `json:"required"`
+	Disabled    bool         `json:"disabled"`
+	Omitted     bool         `json:"omitted"`
+	Validations []Validation `json:"validations,omitempty"`
+}
+
+// FieldItems defines the items of an array field
+type FieldItems struct {
+	Type        FieldType    `json:"type"`
+	LinkType    string       `json:"linkType,omitempty"`
+	Validations []Validation `json:"validations,omitempty"`
+}
+
+// Validation of a field or array items. Only one of the validations is set
+type Validation struct {
+	// LinkContentType restricts the content types an entry link can refer to
+	LinkContentType []string `json:"linkContentType,omitempty"`
+	// LinkMimetypeGroup restricts the mime type groups an asset link can refer to
+	LinkMimetypeGroup []string `json:"linkMimetypeGroup,omitempty"`
+	// In restricts the value to the given values
+	In      []interface{}     `json:"in,omitempty"`
+	Size    *ValidationRange  `json:"size,omitempty"`
+	Range   *ValidationRange  `json:"range,omitempty"`
+	Regexp  *ValidationRegexp `json:"regexp,omitempty"`
+	Unique  bool              `json:"unique,omitempty"`
+	Message string            `json:"message,omitempty"`
+}
+
+// ValidationRange is the allowed range of a value or the allowed size of a text or an array
+type ValidationRange struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// ValidationRegexp is a regular expression the value needs to match
+type ValidationRegexp struct {
+	Pattern string `json:"pattern"`
+	Flags   string `json:"flags,omitempty"`
+}
+
+type contentTypeJSON struct {
+	Sys struct {
+		ID        string    `json:"id"`
+		Type      string    `json:"type"`
+		Revision  int       `json:"revision"`
+		CreatedAt time.Time `json:"createdAt"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	} `json:"sys"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	DisplayField string  `json:"displayField"`
+	Fields       []Field `json:"fields"`
+}
+
+type contentTypesResponse struct {
+	Total int           `json:"total"`
+	Items []ContentType `json:"items"`
+}
+
+// UnmarshalJSON unmarshals the content type from the json returned by the Content Delivery API
+func (c *ContentType) UnmarshalJSON(data []byte) error {
+	var raw contentTypeJSON
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*c = ContentType{
+		ID:           raw.Sys.ID,
+		Name:         raw.Name,
+		Description:  raw.Description,
+		DisplayField: raw.DisplayField,
+		Revision:     raw.Sys.Revision,
+		CreatedAt:    raw.Sys.CreatedAt,
+		UpdatedAt:    raw.Sys.UpdatedAt,
+		Fields:       raw.Fields,
+	}
+	return nil
+}
+
+// MarshalJSON marshals the content type to the json format of the Content Delivery API
+func (c ContentType) MarshalJSON() ([]byte, error) {
+	raw := contentTypeJSON{
+		Name:         c.Name,
+		Description:  c.Description,
+		DisplayField: c.DisplayField,
+		Fields:       c.Fields,
+	}
+	raw.Sys.ID = c.ID
+	raw.Sys.Type = "ContentType"
+	raw.Sys.Revision = c.Revision
+	raw.Sys.CreatedAt = c.CreatedAt
+	raw.Sys.UpdatedAt = c.UpdatedAt
+
+	return json.Marshal(raw)
+}
+
+// Field returns the field with the given ID
+func (c ContentType) Field(id string) (Field, bool) {
+	for _, field := range c.Fields {
+		if field.ID == id {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// LinkContentTypes returns the content types the field, or the items of an array field, can link to.
+// Returns nil if the field is not an entry link or if any content type is allowed
+func (f Field) LinkContentTypes() []string {
+	validations := f.Validations
+	if f.Type == FieldArray && f.Items != nil {
+		validations = f.Items.Validations
+	}
+
+	for _, validation := range validations {
+		if len(validation.LinkContentType) > 0 {
+			return validation.LinkContentType
+		}
+	}
+	return nil
+}
+
+// GetContentTypes returns all the content types of the space
+func (cms *Contentful) GetContentTypes(ctx context.Context) ([]ContentType, error) {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.GetContentTypes")
+	defer span.End()
+
+	var contentTypes []ContentType
+
+	for {
+		query := url.Values{
+			"limit": []string{strconv.Itoa(maxLimit)},
+			"skip":  []string{strconv.Itoa(len(contentTypes))},
+		}
+		response := contentTypesResponse{}
+		err := cms.get(ctx, span, cms.endpoint(ctx, "/content_types"), query, &response)
+		if err != nil {
+			return contentTypes, err
+		}
+
+		contentTypes = append(contentTypes, response.Items...)
+		if len(response.Items) == 0 || len(contentTypes) >= response.Total {
+			return contentTypes, nil
+		}
+	}
+}
+
+// GetContentType returns the content type with the given ID
+func (cms *Contentful) GetContentType(ctx context.Context, id string) (ContentType, error) {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.GetContentType")
+	defer span.End()
+
+	contentType := ContentType{}
+	err := cms.get(ctx, span, cms.endpoint(ctx, "/content_types/"+url.PathEscape(id)), nil, &contentType)
+	return contentType, err
+}
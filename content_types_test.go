@@ -0,0 +1,110 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentful_GetContentTypes(t *testing.T) {
+	t.Parallel()
+
+	bytes, err := ioutil.ReadFile("testdata/content_types.json")
+	assert.NoError(t, err)
+
+	var (
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/spaces/spaceID/content_types":
+				assert.Equal(t, "limit=1000&skip=0", r.URL.RawQuery)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(bytes)
+			case "/spaces/spaceID/content_types/person":
+				response := contentTypesResponse{}
+				err := json.Unmarshal(bytes, &response)
+				assert.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(response.Items[1])
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		cms = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		ctx = context.Background()
+	)
+	defer server.Close()
+
+	t.Run("GetContentTypes", func(t *testing.T) {
+		contentTypes, err := cms.GetContentTypes(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(contentTypes))
+
+		page := contentTypes[0]
+		assert.Equal(t, "page", page.ID)
+		assert.Equal(t, "Page", page.Name)
+		assert.Equal(t, "A page of the site", page.Description)
+		assert.Equal(t, "title", page.DisplayField)
+		assert.Equal(t, 2, page.Revision)
+		assert.Equal(t, 11, len(page.Fields))
+
+		title, ok := page.Field("title")
+		assert.True(t, ok)
+		assert.Equal(t, FieldSymbol, title.Type)
+		assert.True(t, title.Localized)
+		assert.True(t, title.Required)
+		assert.True(t, title.Validations[0].Unique)
+		assert.Nil(t, title.LinkContentTypes())
+
+		order, _ := page.Field("order")
+		assert.Equal(t, 100.0, *order.Validations[0].Range.Max)
+
+		banner, _ := page.Field("banner")
+		assert.Equal(t, FieldLink, banner.Type)
+		assert.Equal(t, linkTypeAsset, banner.LinkType)
+		assert.Equal(t, []string{"image"}, banner.Validations[0].LinkMimetypeGroup)
+
+		author, _ := page.Field("author")
+		assert.Equal(t, []string{"person"}, author.LinkContentTypes())
+
+		subPages, _ := page.Field("subPages")
+		assert.Equal(t, FieldArray, subPages.Type)
+		assert.Equal(t, FieldLink, subPages.Items.Type)
+		assert.Equal(t, linkTypeEntry, subPages.Items.LinkType)
+		assert.Equal(t, []string{"page"}, subPages.LinkContentTypes())
+
+		tags, _ := page.Field("tags")
+		assert.Equal(t, []interface{}{"news", "blog"}, tags.Items.Validations[0].In)
+
+		_, ok = page.Field("unknown")
+		assert.False(t, ok)
+	})
+
+	t.Run("GetContentType", func(t *testing.T) {
+		person, err := cms.GetContentType(ctx, "person")
+		assert.NoError(t, err)
+		assert.Equal(t, "person", person.ID)
+		assert.Equal(t, "name", person.DisplayField)
+		assert.Equal(t, 5, len(person.Fields))
+
+		_, err = cms.GetContentType(ctx, "unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("Marshals to the same format", func(t *testing.T) {
+		response := contentTypesResponse{}
+		err := json.Unmarshal(bytes, &response)
+		assert.NoError(t, err)
+
+		marshaled, err := json.Marshal(response.Items[0])
+		assert.NoError(t, err)
+		var page ContentType
+		err = json.Unmarshal(marshaled, &page)
+		assert.NoError(t, err)
+		assert.Equal(t, response.Items[0], page)
+	})
+}
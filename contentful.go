@@ -1,11 +1,19 @@
 // Package contentful provides a Contentful (https://www.contentful.com/) client
 package contentful
 
-import "time"
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
 
 const (
 	previewURL = "https://preview.contentful.com"
 	cdnURL     = "https://cdn.contentful.com"
+
+	defaultUserAgent = "contentful-go/v2"
 )
 
 // Information about the entry or asset
@@ -28,28 +36,186 @@ type Asset struct {
 
 // File of an asset
 type File struct {
-	URL         string `json:"url"`
-	FileName    string `json:"fileName"`
-	ContentType string `json:"contentType"`
+	// URL is protocol relative, e.g. "//images.ctfassets.net/space/asset/id/image.png". See AbsoluteURL
+	URL         string      `json:"url"`
+	FileName    string      `json:"fileName"`
+	ContentType string      `json:"contentType"`
+	Details     FileDetails `json:"details"`
+}
+
+// FileDetails holds the size of the file and the dimensions of an image
+type FileDetails struct {
+	// Size in bytes
+	Size  int          `json:"size"`
+	Image ImageDetails `json:"image"`
+}
+
+// ImageDetails holds the dimensions of an image in pixels. Zero for files which are not images
+type ImageDetails struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// AbsoluteURL returns the URL of the file with the given scheme, e.g. "https".
+// Defaults to "https" if scheme is empty
+func (f File) AbsoluteURL(scheme string) string {
+	if f.URL == "" || !strings.HasPrefix(f.URL, "//") {
+		return f.URL
+	}
+	if scheme == "" {
+		scheme = "https"
+	}
+	return scheme + ":" + f.URL
+}
+
+// IsImage returns true if the file is an image
+func (f File) IsImage() bool {
+	return strings.HasPrefix(f.ContentType, "image/")
+}
+
+// Location is the value of a location field
+type Location struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
 }
 
 // Contentful client for fetching data from Contentful
 type Contentful struct {
-	token   string
-	spaceID string
-	url     string
+	token       string
+	spaceID     string
+	url         string
+	environment string
+	preview     bool
+	userAgent   string
+	timeout     time.Duration
+	client      *http.Client
+
+	maxLinkRequests int
+	cyclePolicy     CyclePolicy
+	maxDepth        int
+	warningHandler  func(Warning)
+	retryPolicy     *RetryPolicy
+	limiter         *rateLimiter
+	cache           *cache
+	flights         *flightGroup
+}
+
+// Option configures the Contentful client created with NewWithOptions
+type Option func(*Contentful)
+
+// WithHTTPClient sets the http.Client used for the requests. Use this to configure e.g. transports, proxies or TLS.
+// Defaults to http.DefaultClient
+func WithHTTPClient(client *http.Client) Option {
+	return func(cms *Contentful) {
+		cms.client = client
+	}
+}
+
+// WithBaseURL sets the base URL of the API, e.g. "https://cdn.eu.contentful.com".
+// Overrides the URL selected by WithPreview
+func WithBaseURL(baseURL string) Option {
+	return func(cms *Contentful) {
+		cms.url = baseURL
+	}
+}
+
+// WithPreview selects whether to use the preview API or the delivery API
+func WithPreview(preview bool) Option {
+	return func(cms *Contentful) {
+		cms.preview = preview
+	}
+}
+
+// WithEnvironment sets the environment to fetch the data from. Defaults to the master environment.
+// Environment aliases are resolved by Contentful, so an alias ID can be used in place of an environment ID.
+// Use ContextWithEnvironment to override the environment for a single call
+func WithEnvironment(environment string) Option {
+	return func(cms *Contentful) {
+		cms.environment = environment
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(cms *Contentful) {
+		cms.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets a timeout for each HTTP request. The http.Client given with WithHTTPClient
+// will not be modified, a copy of it will be used instead
+func WithTimeout(timeout time.Duration) Option {
+	return func(cms *Contentful) {
+		cms.timeout = timeout
+	}
 }
 
 // New creates a new Contentful client
 func New(token string, spaceID string, preview bool) *Contentful {
-	u := cdnURL
-	if preview {
-		u = previewURL
+	return NewWithOptions(token, spaceID, WithPreview(preview))
+}
+
+// NewWithOptions creates a new Contentful client configured with the given options
+func NewWithOptions(token string, spaceID string, options ...Option) *Contentful {
+	cms := &Contentful{
+		token:     token,
+		spaceID:   spaceID,
+		userAgent: defaultUserAgent,
+		flights:   newFlightGroup(),
+	}
+
+	for _, option := range options {
+		option(cms)
+	}
+
+	if cms.url == "" {
+		cms.url = cdnURL
+		if cms.preview {
+			cms.url = previewURL
+		}
 	}
 
-	return &Contentful{
-		token:   token,
-		spaceID: spaceID,
-		url:     u,
+	if cms.timeout > 0 {
+		client := http.Client{}
+		if cms.client != nil {
+			client = *cms.client
+		}
+		client.Timeout = cms.timeout
+		cms.client = &client
+	}
+
+	return cms
+}
+
+func (cms *Contentful) httpClient() *http.Client {
+	if cms.client == nil {
+		return http.DefaultClient
+	}
+	return cms.client
+}
+
+type environmentKey struct{}
+
+// ContextWithEnvironment returns a copy of ctx which overrides the client's environment (see WithEnvironment)
+// for all calls made with it
+func ContextWithEnvironment(ctx context.Context, environment string) context.Context {
+	return context.WithValue(ctx, environmentKey{}, environment)
+}
+
+// environmentFor returns the environment to use for a call made with ctx
+func (cms *Contentful) environmentFor(ctx context.Context) string {
+	if environment, ok := ctx.Value(environmentKey{}).(string); ok && environment != "" {
+		return environment
+	}
+	return cms.environment
+}
+
+// endpoint returns the full url for the given path, e.g. "/entries", in the client's space and
+// in the environment selected for ctx
+func (cms *Contentful) endpoint(ctx context.Context, path string) string {
+	u := cms.url + "/spaces/" + url.PathEscape(cms.spaceID)
+	if environment := cms.environmentFor(ctx); environment != "" {
+		u += "/environments/" + url.PathEscape(environment)
 	}
+	return u + path
 }
@@ -1,7 +1,12 @@
 package contentful
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -18,3 +23,129 @@ func TestNew(t *testing.T) {
 	cms = New("token", "space", true)
 	assert.Equal(previewURL, cms.url)
 }
+
+func TestNewWithOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Defaults", func(t *testing.T) {
+		cms := NewWithOptions("token", "space")
+		assert.Equal(t, "token", cms.token)
+		assert.Equal(t, "space", cms.spaceID)
+		assert.Equal(t, cdnURL, cms.url)
+		assert.Equal(t, "", cms.environment)
+		assert.Equal(t, defaultUserAgent, cms.userAgent)
+		assert.Equal(t, http.DefaultClient, cms.httpClient())
+	})
+
+	t.Run("Base URL overrides preview", func(t *testing.T) {
+		cms := NewWithOptions("token", "space", WithPreview(true))
+		assert.Equal(t, previewURL, cms.url)
+
+		cms = NewWithOptions("token", "space", WithBaseURL("https://cdn.eu.contentful.com"), WithPreview(true))
+		assert.Equal(t, "https://cdn.eu.contentful.com", cms.url)
+	})
+
+	t.Run("Timeout doesn't modify the given client", func(t *testing.T) {
+		client := &http.Client{}
+		cms := NewWithOptions("token", "space", WithHTTPClient(client), WithTimeout(time.Second))
+		assert.Equal(t, time.Duration(0), client.Timeout)
+		assert.Equal(t, time.Second, cms.httpClient().Timeout)
+		assert.True(t, client != cms.httpClient())
+	})
+
+	t.Run("Options are used in requests", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			assert.Equal(t, "/spaces/space/environments/staging/entries", r.URL.Path)
+			assert.Equal(t, "my-agent", r.Header.Get("User-Agent"))
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		cms := NewWithOptions(
+			"token",
+			"space",
+			WithBaseURL(server.URL),
+			WithHTTPClient(server.Client()),
+			WithEnvironment("staging"),
+			WithUserAgent("my-agent"),
+		)
+		_, err := cms.search(context.Background(), Parameters())
+		assert.Error(t, err)
+		assert.True(t, called)
+	})
+}
+
+func TestContentful_environment(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path   string
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.EscapedPath()
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"total": 0, "items": []}`))
+		}))
+	)
+	defer server.Close()
+
+	cases := []struct {
+		name        string
+		environment string
+		override    string
+		expected    string
+	}{
+		{"No environment uses the master environment", "", "", "/spaces/space/entries"},
+		{"Client environment", "staging", "", "/spaces/space/environments/staging/entries"},
+		{"Environment alias", "master-alias", "", "/spaces/space/environments/master-alias/entries"},
+		{"Override from context", "staging", "feature-foo", "/spaces/space/environments/feature-foo/entries"},
+		{"Override without client environment", "", "feature-foo", "/spaces/space/environments/feature-foo/entries"},
+		{"Environment is escaped", "feature/foo", "", "/spaces/space/environments/feature%2Ffoo/entries"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cms := NewWithOptions("token", "space", WithBaseURL(server.URL), WithEnvironment(c.environment))
+			ctx := context.Background()
+			if c.override != "" {
+				ctx = ContextWithEnvironment(ctx, c.override)
+			}
+
+			_, err := cms.search(ctx, Parameters())
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, path)
+			assert.Equal(t, server.URL+c.expected, cms.endpoint(ctx, "/entries"))
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	f := File{
+		URL:         "//images.ctfassets.net/space/asset/id/green.png",
+		ContentType: "image/png",
+	}
+	assert.Equal(t, "https://images.ctfassets.net/space/asset/id/green.png", f.AbsoluteURL("https"))
+	assert.Equal(t, "https://images.ctfassets.net/space/asset/id/green.png", f.AbsoluteURL(""))
+	assert.Equal(t, "http://images.ctfassets.net/space/asset/id/green.png", f.AbsoluteURL("http"))
+	assert.True(t, f.IsImage())
+
+	f = File{URL: "https://assets.ctfassets.net/space/asset/id/file.pdf", ContentType: "application/pdf"}
+	assert.Equal(t, "https://assets.ctfassets.net/space/asset/id/file.pdf", f.AbsoluteURL("http"))
+	assert.False(t, f.IsImage())
+
+	assert.Equal(t, "", File{}.AbsoluteURL("https"))
+}
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	var store struct {
+		Location Location `json:"location"`
+	}
+	err := json.Unmarshal([]byte(`{"location": {"lat": 60.1699, "lon": 24.9384}}`), &store)
+	assert.NoError(t, err)
+	assert.Equal(t, Location{Lat: 60.1699, Lon: 24.9384}, store.Location)
+}
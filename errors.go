@@ -0,0 +1,183 @@
+package contentful
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"go.opencensus.io/trace"
+)
+
+// Error IDs of Contentful, see https://www.contentful.com/developers/docs/references/errors/
+const (
+	errorIDNotFound           = "NotFound"
+	errorIDAccessTokenInvalid = "AccessTokenInvalid"
+	errorIDInvalidQuery       = "InvalidQuery"
+)
+
+// APIError is returned when Contentful responds with a non-ok status code. Example of the response:
+//   {
+//     "sys": {
+//       "type": "Error",
+//       "id": "NotFound"
+//     },
+//     "message": "The resource could not be found.",
+//     "details": {
+//       "type": "Entry",
+//       "id": "entryID"
+//     },
+//     "requestId": "a1b2c3"
+//   }
+type APIError struct {
+	// StatusCode of the response
+	StatusCode int
+	// ID of the error, e.g. "NotFound", "AccessTokenInvalid" or "InvalidQuery"
+	ID string
+	// Message describing the error
+	Message string
+	// Details of the error as returned by Contentful
+	Details json.RawMessage
+	// Errors has the validation errors of the details, e.g. for an "InvalidQuery" error
+	Errors []APIErrorDetail
+	// RequestID from the X-Contentful-Request-Id header, which Contentful support asks for
+	RequestID string
+	// URL of the request with the access token redacted
+	URL string
+}
+
+// APIErrorDetail is a validation error in the details of an APIError
+type APIErrorDetail struct {
+	Name    string        `json:"name"`
+	Path    []interface{} `json:"path"`
+	Details string        `json:"details"`
+	Value   interface{}   `json:"value"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("contentful: non-ok status code %d", e.StatusCode)
+	if e.ID != "" {
+		msg += " " + e.ID
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request ID " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// IsNotFound returns true if err is an *APIError for a resource, which doesn't exist
+func IsNotFound(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusNotFound || apiErr.ID == errorIDNotFound)
+}
+
+// IsUnauthorized returns true if err is an *APIError for a missing or an invalid access token
+func IsUnauthorized(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.ID == errorIDAccessTokenInvalid)
+}
+
+// IsInvalidQuery returns true if err is an *APIError for invalid search parameters
+func IsInvalidQuery(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.ID == errorIDInvalidQuery
+}
+
+// IsRateLimited returns true if err is an *APIError for a request, which Contentful rate limited
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrTooManyRequests)
+}
+
+// Is returns true if target is ErrTooManyRequests and Contentful rate limited the request
+func (e *APIError) Is(target error) bool {
+	return target == ErrTooManyRequests && e.StatusCode == http.StatusTooManyRequests
+}
+
+// asAPIError finds an *APIError from the chain of wrapped errors
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// newAPIError reads the error from the response. The body is optional, so the error is returned even if it
+// can't be parsed
+func newAPIError(resp *http.Response, requestURL string) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Contentful-Request-Id"),
+		URL:        redactURL(requestURL),
+	}
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return apiErr
+	}
+
+	var body struct {
+		Sys struct {
+			ID string `json:"id"`
+		} `json:"sys"`
+		Message   string          `json:"message"`
+		Details   json.RawMessage `json:"details"`
+		RequestID string          `json:"requestId"`
+	}
+	if json.Unmarshal(bytes, &body) != nil {
+		return apiErr
+	}
+
+	apiErr.ID = body.Sys.ID
+	apiErr.Message = body.Message
+	apiErr.Details = body.Details
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = body.RequestID
+	}
+
+	var details struct {
+		Errors []APIErrorDetail `json:"errors"`
+	}
+	if len(body.Details) > 0 && json.Unmarshal(body.Details, &details) == nil {
+		apiErr.Errors = details.Errors
+	}
+
+	return apiErr
+}
+
+// spanStatus returns the status code of the span for the error
+func (e *APIError) spanStatus() int32 {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return trace.StatusCodeInvalidArgument
+	case http.StatusUnauthorized:
+		return trace.StatusCodeUnauthenticated
+	case http.StatusForbidden:
+		return trace.StatusCodePermissionDenied
+	case http.StatusNotFound:
+		return trace.StatusCodeNotFound
+	case http.StatusTooManyRequests:
+		return trace.StatusCodeResourceExhausted
+	}
+	return trace.StatusCodeUnknown
+}
+
+// redactURL removes the access token from the query of the URL
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	query := parsed.Query()
+	if query.Get("access_token") != "" {
+		query.Set("access_token", "REDACTED")
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
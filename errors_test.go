@@ -0,0 +1,137 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type wrappedError struct {
+	err error
+}
+
+func (e wrappedError) Error() string {
+	return "wrapped: " + e.err.Error()
+}
+
+func (e wrappedError) Unwrap() error {
+	return e.err
+}
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Contentful-Request-Id", "requestID")
+		switch r.URL.Query().Get("content_type") {
+		case "invalid":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{
+				"sys": {"type": "Error", "id": "InvalidQuery"},
+				"message": "The query you sent was invalid. Probably a filter or ordering specification is not applicable to the type of a field.",
+				"details": {"errors": [{"name": "unknown", "path": ["fields", "foo"], "details": "The path \"fields.foo\" is not recognized"}]}
+			}`))
+		case "limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"sys": {"type": "Error", "id": "RateLimitExceeded"}, "message": "You have exceeded the rate limit."}`))
+		case "unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"sys": {"type": "Error", "id": "AccessTokenInvalid"}, "message": "The access token you sent could not be found or is invalid."}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"sys": {"type": "Error", "id": "NotFound"}, "message": "The resource could not be found.", "details": {"type": "Entry", "id": "entryID"}}`))
+		}
+	}))
+	defer server.Close()
+
+	var (
+		cms = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		ctx = context.Background()
+	)
+
+	t.Run("Not found", func(t *testing.T) {
+		var result map[string]interface{}
+		err := cms.GetOne(ctx, Parameters().ByID("entryID"), &result)
+		apiErr, ok := err.(*APIError)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "NotFound", apiErr.ID)
+		assert.Equal(t, "requestID", apiErr.RequestID)
+		assert.Equal(t, server.URL+"/spaces/spaceID/entries?include=10&sys.id=entryID", apiErr.URL)
+		assert.JSONEq(t, `{"type": "Entry", "id": "entryID"}`, string(apiErr.Details))
+		assert.Equal(t, "contentful: non-ok status code 404 NotFound: The resource could not be found. (request ID requestID)", err.Error())
+
+		assert.True(t, IsNotFound(err))
+		assert.True(t, IsNotFound(wrappedError{err}))
+		var wrapped *APIError
+		assert.True(t, errors.As(wrappedError{err}, &wrapped))
+		assert.Equal(t, apiErr, wrapped)
+		assert.False(t, IsUnauthorized(err))
+		assert.False(t, IsInvalidQuery(err))
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		var result map[string]interface{}
+		err := cms.GetOne(ctx, Parameters().ByContentType("unauthorized"), &result)
+		assert.True(t, IsUnauthorized(err))
+		assert.False(t, IsNotFound(err))
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		var result map[string]interface{}
+		err := cms.GetOne(ctx, Parameters().ByContentType("invalid"), &result)
+		assert.True(t, IsInvalidQuery(err))
+		apiErr, ok := err.(*APIError)
+		if assert.True(t, ok) {
+			assert.Equal(t, []APIErrorDetail{{
+				Name:    "unknown",
+				Path:    []interface{}{"fields", "foo"},
+				Details: `The path "fields.foo" is not recognized`,
+			}}, apiErr.Errors)
+		}
+	})
+
+	t.Run("Rate limited", func(t *testing.T) {
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+		var result map[string]interface{}
+		err := cms.GetOne(ctx, Parameters().ByContentType("limited"), &result)
+		apiErr, ok := err.(*APIError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+			assert.Equal(t, "RateLimitExceeded", apiErr.ID)
+			assert.Equal(t, "requestID", apiErr.RequestID)
+		}
+		assert.True(t, errors.Is(err, ErrTooManyRequests))
+		assert.True(t, IsRateLimited(err))
+		assert.True(t, IsRateLimited(wrappedError{err}))
+		assert.False(t, IsNotFound(err))
+	})
+
+	t.Run("Other errors", func(t *testing.T) {
+		assert.False(t, IsRateLimited(&APIError{StatusCode: http.StatusNotFound}))
+		assert.False(t, errors.Is(&APIError{StatusCode: http.StatusTooManyRequests}, ErrNoEntries))
+		assert.False(t, IsNotFound(nil))
+		assert.False(t, IsNotFound(errors.New("error")))
+		assert.False(t, IsNotFound(wrappedError{errors.New("error")}))
+	})
+}
+
+func TestNewAPIError(t *testing.T) {
+	t.Parallel()
+
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusInternalServerError)
+	_, _ = recorder.WriteString("not json")
+
+	apiErr := newAPIError(recorder.Result(), "https://cdn.contentful.com/spaces/spaceID/entries?access_token=token&limit=1")
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "", apiErr.ID)
+	assert.Equal(t, "https://cdn.contentful.com/spaces/spaceID/entries?access_token=REDACTED&limit=1", apiErr.URL)
+	assert.Equal(t, "contentful: non-ok status code 500", apiErr.Error())
+}
@@ -272,7 +272,7 @@ func ExampleParameters() {
 
 	fmt.Println(page.Title)
 	// Output:
-	// ByFieldValue requires that ByContentType is called: non-ok status code: 400
+	// ByFieldValue requires that ByContentType is called: contentful: invalid search parameter fields.title: searching by fields requires that content type is set
 	// Sub page
 }
 
@@ -382,8 +382,16 @@ func ExampleFile() {
 	fmt.Println(page.Banner.File.FileName)
 	fmt.Println(page.Banner.File.ContentType)
 	fmt.Println(strings.Split(page.Banner.File.URL, "/")[2])
+	fmt.Println(page.Banner.File.Details.Size)
+	fmt.Println(page.Banner.File.Details.Image.Width)
+	fmt.Println(page.Banner.File.Details.Image.Height)
+	fmt.Println(strings.HasPrefix(page.Banner.File.AbsoluteURL("https"), "https://images.ctfassets.net/"))
 	// Output:
 	// green.png
 	// image/png
 	// images.ctfassets.net
+	// 1508
+	// 100
+	// 100
+	// true
 }
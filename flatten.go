@@ -3,6 +3,7 @@ package contentful
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -28,6 +29,7 @@ type itemInfo struct {
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 	Locale    string    `json:"locale"`
+	DeletedAt time.Time `json:"deletedAt"`
 }
 
 type item struct {
@@ -41,17 +43,106 @@ type includes struct {
 }
 
 type searchResults struct {
-	Total    int      `json:"total"`
-	Skip     int      `json:"skip"`
-	Limit    int      `json:"limit"`
-	Items    []item   `json:"items"`
-	Includes includes `json:"includes"`
+	Total    int             `json:"total"`
+	Skip     int             `json:"skip"`
+	Limit    int             `json:"limit"`
+	Items    []item          `json:"items"`
+	Includes includes        `json:"includes"`
+	Errors   []responseError `json:"errors"`
 }
 
-func flattenItems(includes includes, items []item) ([]map[string]interface{}, error) {
+// LinkStrategy defines what to do when a link's target is not in the response, e.g. because it's deeper than
+// the include depth, see SearchParameters.Include
+type LinkStrategy int
+
+const (
+	// LinkFail returns an error. This is the default
+	LinkFail LinkStrategy = iota
+	// LinkRaw leaves the link object, e.g. {"sys": {"type": "Link", "linkType": "Entry", "id": "entryID"}}
+	LinkRaw
+	// LinkNull sets null in place of the link
+	LinkNull
+	// LinkFetch fetches the links' targets with additional requests, see WithMaxLinkRequests.
+	// Returns an error for the links, which still couldn't be resolved
+	LinkFetch
+)
+
+// CyclePolicy defines what to do when references form a cycle, e.g. two pages linking to each other
+type CyclePolicy int
+
+const (
+	// CycleStub replaces the reference closing the cycle with a stub, which has only the Information fields.
+	// This is the default
+	CycleStub CyclePolicy = iota
+	// CycleFail returns a *ReferenceCycleError
+	CycleFail
+	// CycleMaxDepth resolves the references until the maximum depth, see WithMaxDepth,
+	// and replaces the references deeper than that with stubs
+	CycleMaxDepth
+)
+
+// defaultMaxDepth is the default depth of the references resolved with CycleMaxDepth
+const defaultMaxDepth = maxInclude
+
+// WithCyclePolicy sets what to do when the references form a cycle. Defaults to CycleStub
+func WithCyclePolicy(policy CyclePolicy) Option {
+	return func(cms *Contentful) {
+		cms.cyclePolicy = policy
+	}
+}
+
+// WithMaxDepth sets the depth of the references resolved when CycleMaxDepth is used. Defaults to 10
+func WithMaxDepth(depth int) Option {
+	return func(cms *Contentful) {
+		cms.maxDepth = depth
+	}
+}
+
+// ReferenceCycleError is returned when the references form a cycle and CycleFail is used
+type ReferenceCycleError struct {
+	// Path of the references as "<link type> <id>", starting and ending with the same reference
+	Path []string
+}
+
+func (e *ReferenceCycleError) Error() string {
+	return "contentful: reference cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// flattener flattens the items of a response by injecting the references from includes
+type flattener struct {
+	includes     includes
+	missingLinks LinkStrategy
+	cyclePolicy  CyclePolicy
+	maxDepth     int
+	// unresolvable links are dropped, see unresolvableLinks
+	unresolvable map[string]bool
+	// path has the references being flattened, starting from the item
+	path []sys
+}
+
+// droppedLink is returned from fetchReference for the unresolvable links. It's removed from arrays
+// and replaced with null elsewhere
+type droppedLink struct{}
+
+func newFlattener(includes includes, missingLinks LinkStrategy) *flattener {
+	return &flattener{includes: includes, missingLinks: missingLinks, maxDepth: defaultMaxDepth}
+}
+
+// newFlattener returns a flattener for the response configured with the client's cycle policy
+func (cms *Contentful) newFlattener(response searchResults, missingLinks LinkStrategy) *flattener {
+	f := newFlattener(response.Includes, missingLinks)
+	f.unresolvable = unresolvableLinks(response.Errors)
+	f.cyclePolicy = cms.cyclePolicy
+	if cms.maxDepth > 0 {
+		f.maxDepth = cms.maxDepth
+	}
+	return f
+}
+
+func (f *flattener) flattenItems(items []item) ([]map[string]interface{}, error) {
 	flattenedItems := make([]map[string]interface{}, len(items))
 	for i, item := range items {
-		flattenedItem, err := flattenItem(includes, item)
+		flattenedItem, err := f.flattenItem(item)
 		if err != nil {
 			return flattenedItems, err
 		}
@@ -62,27 +153,56 @@ func flattenItems(includes includes, items []item) ([]map[string]interface{}, er
 	return flattenedItems, nil
 }
 
-func flattenItem(includes includes, item item) (map[string]interface{}, error) {
+func (f *flattener) flattenItem(item item) (map[string]interface{}, error) {
 	flattenedFields := make(map[string]interface{}, len(item.Fields))
 
+	if item.Sys.ID != "" {
+		linkType := item.Sys.Type
+		if linkType == "" {
+			linkType = linkTypeEntry
+		}
+		f.path = append(f.path, sys{LinkType: linkType, ID: item.Sys.ID})
+		defer func() {
+			f.path = f.path[:len(f.path)-1]
+		}()
+	}
+
 	for key, field := range item.Fields {
-		flattenedField, err := flattenField(includes, field)
+		flattenedField, err := f.flattenField(field)
 		if err != nil {
 			return flattenedFields, err
 		}
+		if _, dropped := flattenedField.(droppedLink); dropped {
+			flattenedField = nil
+		}
 		flattenedFields[key] = flattenedField
 	}
 
+	flattenInformation(item, flattenedFields)
+	return flattenedFields, nil
+}
+
+// flattenInformation sets the information of the item to the flattened fields.
+// Set only the information available, because "select" parameter can leave out any of them
+func flattenInformation(item item, flattenedFields map[string]interface{}) {
 	if item.Sys.ID != "" {
 		flattenedFields["contentfulId"] = item.Sys.ID
+	}
+	if item.Sys.ContentType.Sys.ID != "" {
 		flattenedFields["contentfulContentType"] = item.Sys.ContentType.Sys.ID
+	}
+	if item.Sys.Revision != 0 {
 		flattenedFields["contentfulRevision"] = item.Sys.Revision
+	}
+	if !item.Sys.CreatedAt.IsZero() {
 		flattenedFields["contentfulCreatedAt"] = item.Sys.CreatedAt
+	}
+	if !item.Sys.UpdatedAt.IsZero() {
 		flattenedFields["contentfulUpdatedAt"] = item.Sys.UpdatedAt
+	}
+	if item.Sys.Locale != "" {
 		flattenedFields["contentfulLocale"] = item.Sys.Locale
 	}
-
-	return flattenedFields, nil
 }
 
 // flattenField injects the references from "includes" object to the field therefore flattening the json.
@@ -147,17 +267,20 @@ func flattenItem(includes includes, item item) (map[string]interface{}, error) {
 //   "reference": {
 //     "key": "value"
 //   }
-func flattenField(includes includes, field interface{}) (interface{}, error) {
+func (f *flattener) flattenField(field interface{}) (interface{}, error) {
 	switch t := field.(type) {
 	// Either multiple references or values, flatten each individually
 	case []interface{}:
-		flattenedFields := make([]interface{}, len(t))
-		for i, v := range t {
-			flattenedField, err := flattenField(includes, v)
+		flattenedFields := make([]interface{}, 0, len(t))
+		for _, v := range t {
+			flattenedField, err := f.flattenField(v)
 			if err != nil {
 				return field, err
 			}
-			flattenedFields[i] = flattenedField
+			if _, dropped := flattenedField.(droppedLink); dropped {
+				continue
+			}
+			flattenedFields = append(flattenedFields, flattenedField)
 		}
 		return flattenedFields, nil
 
@@ -165,11 +288,11 @@ func flattenField(includes includes, field interface{}) (interface{}, error) {
 	case map[string]interface{}:
 		// Reference
 		if sys, ok := parseToSys(t["sys"]); ok {
-			return fetchReference(includes, sys)
+			return f.fetchReference(sys)
 		}
 
 		// Field is not a reference but an object. Flatten like as if it were an item in search result.
-		flattenedItem, err := flattenItem(includes, item{Fields: t})
+		flattenedItem, err := f.flattenItem(item{Fields: t})
 		if err != nil {
 			return field, err
 		}
@@ -209,38 +332,90 @@ func parseToSys(field interface{}) (sys, bool) {
 	return sys, sys.ID != "" && (sys.LinkType == linkTypeAsset || sys.LinkType == linkTypeEntry) && sys.Type == linkType
 }
 
-func fetchReference(includes includes, sys sys) (interface{}, error) {
-	var (
-		references []item
-		found      bool
-		item       item
-	)
-
-	if sys.LinkType == linkTypeEntry {
-		references = includes.Entry
-	} else if sys.LinkType == linkTypeAsset {
-		references = includes.Asset
-	} else {
+func (f *flattener) fetchReference(sys sys) (interface{}, error) {
+	if sys.LinkType != linkTypeEntry && sys.LinkType != linkTypeAsset {
 		return struct{}{}, fmt.Errorf("link type is not %s or %s, but instead %s", linkTypeEntry, linkTypeAsset, sys.LinkType)
 	}
 
-	for _, ref := range references {
-		if ref.Sys.ID == sys.ID && ref.Sys.Type == sys.LinkType {
-			found = true
-			item = ref
-			break
+	if item, found := f.findReference(sys); found {
+		return f.resolveFound(item, sys)
+	}
+
+	if f.unresolvable[sys.LinkType+":"+sys.ID] {
+		return droppedLink{}, nil
+	}
+
+	switch f.missingLinks {
+	case LinkRaw:
+		return map[string]interface{}{
+			"sys": map[string]interface{}{
+				"type":     sys.Type,
+				"linkType": sys.LinkType,
+				"id":       sys.ID,
+			},
+		}, nil
+	case LinkNull:
+		return nil, nil
+	}
+
+	references := f.includes.Entry
+	if sys.LinkType == linkTypeAsset {
+		references = f.includes.Asset
+	}
+	refString := "Could not convert to string"
+	bytes, err := json.MarshalIndent(references, "", "  ")
+	if err == nil {
+		refString = string(bytes)
+	}
+	return struct{}{}, fmt.Errorf("could not find a reference with type %s and with id %s.\nReferences:\n%s", sys.LinkType, sys.ID, refString)
+}
+
+// stub returns the item flattened without its fields
+// resolveFound flattens the found reference, or handles it according to the cycle policy if it's already in the path
+func (f *flattener) resolveFound(item item, sys sys) (interface{}, error) {
+	if f.cyclePolicy == CycleMaxDepth {
+		// The first reference in the path is the item itself
+		if len(f.path) > f.maxDepth {
+			return f.stub(item), nil
+		}
+		return f.flattenItem(item)
+	}
+
+	for i, ref := range f.path {
+		if ref.LinkType != sys.LinkType || ref.ID != sys.ID {
+			continue
+		}
+		if f.cyclePolicy == CycleFail {
+			path := make([]string, 0, len(f.path)-i+1)
+			for _, ref := range f.path[i:] {
+				path = append(path, ref.LinkType+" "+ref.ID)
+			}
+			path = append(path, sys.LinkType+" "+sys.ID)
+			return struct{}{}, &ReferenceCycleError{Path: path}
 		}
+		return f.stub(item), nil
 	}
 
-	if !found {
-		// TODO: try to fetch data separately
-		refString := "Could not convert to string"
-		bytes, err := json.MarshalIndent(references, "", "  ")
-		if err == nil {
-			refString = string(bytes)
+	return f.flattenItem(item)
+}
+
+func (f *flattener) stub(item item) map[string]interface{} {
+	flattenedFields := make(map[string]interface{})
+	flattenInformation(item, flattenedFields)
+	return flattenedFields
+}
+
+func (f *flattener) findReference(sys sys) (item, bool) {
+	references := f.includes.Entry
+	if sys.LinkType == linkTypeAsset {
+		references = f.includes.Asset
+	}
+
+	for _, ref := range references {
+		if ref.Sys.ID == sys.ID && ref.Sys.Type == sys.LinkType {
+			return ref, true
 		}
-		return struct{}{}, fmt.Errorf("could not find a reference with type %s and with id %s.\nReferences:\n%s", sys.LinkType, sys.ID, refString)
 	}
 
-	return flattenItem(includes, item)
+	return item{}, false
 }
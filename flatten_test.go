@@ -1,6 +1,10 @@
 package contentful
 
 import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,8 +75,91 @@ func TestParseToSys(t *testing.T) {
 }
 
 func TestFetchReferenceWrongIncludeType(t *testing.T) {
-	_, err := fetchReference(includes{}, sys{
+	_, err := newFlattener(includes{}, LinkFail).fetchReference(sys{
 		LinkType: "linkType",
 	})
 	assert.NotNil(t, err)
 }
+
+func TestFlattenItemSparseSys(t *testing.T) {
+	flattened, err := newFlattener(includes{}, LinkFail).flattenItem(item{
+		Sys:    itemInfo{ID: "id", Revision: 2},
+		Fields: map[string]interface{}{"title": "Title"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"title":              "Title",
+		"contentfulId":       "id",
+		"contentfulRevision": 2,
+	}, flattened)
+
+	flattened, err = newFlattener(includes{}, LinkFail).flattenItem(item{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{}, flattened)
+}
+
+func TestContentful_GetCycles(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bytes, err := ioutil.ReadFile("testdata/cyclic_pages.json")
+		assert.NoError(t, err)
+		_, err = w.Write(bytes)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	type page struct {
+		Title   string `json:"title"`
+		Related []page `json:"related"`
+		Information
+	}
+	var ctx = context.Background()
+
+	t.Run("Stub", func(t *testing.T) {
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		var pages []page
+		err := cms.GetMany(ctx, Parameters(), &pages)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(pages))
+
+		subPage := pages[0].Related[0]
+		assert.Equal(t, "Sub page", subPage.Title)
+		stub := subPage.Related[0]
+		assert.Equal(t, "mainPage", stub.ID)
+		assert.Equal(t, "page", stub.ContentType)
+		assert.Equal(t, 1, stub.Revision)
+		assert.Equal(t, "", stub.Title)
+		assert.Nil(t, stub.Related)
+
+		assert.Equal(t, "selfPage", pages[1].Related[0].ID)
+		assert.Equal(t, "", pages[1].Related[0].Title)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithCyclePolicy(CycleFail))
+		var pages []page
+		err := cms.GetMany(ctx, Parameters(), &pages)
+		cycleErr, ok := err.(*ReferenceCycleError)
+		if assert.True(t, ok) {
+			assert.Equal(t, []string{"Entry mainPage", "Entry subPage", "Entry mainPage"}, cycleErr.Path)
+		}
+		assert.EqualError(t, err, "contentful: reference cycle: Entry mainPage -> Entry subPage -> Entry mainPage")
+	})
+
+	t.Run("Max depth", func(t *testing.T) {
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithCyclePolicy(CycleMaxDepth), WithMaxDepth(3))
+		var pages []page
+		err := cms.GetMany(ctx, Parameters(), &pages)
+		assert.NoError(t, err)
+
+		depth1 := pages[0].Related[0]
+		depth2 := depth1.Related[0]
+		depth3 := depth2.Related[0]
+		assert.Equal(t, "Sub page", depth1.Title)
+		assert.Equal(t, "Main page", depth2.Title)
+		assert.Equal(t, "Sub page", depth3.Title)
+		assert.Equal(t, "mainPage", depth3.Related[0].ID)
+		assert.Equal(t, "", depth3.Related[0].Title)
+	})
+}
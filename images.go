@@ -0,0 +1,341 @@
+package contentful
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ImageFormat is the format an image is converted to by the Images API
+type ImageFormat string
+
+// Image formats supported by the Images API
+const (
+	JPG  ImageFormat = "jpg"
+	PNG  ImageFormat = "png"
+	WebP ImageFormat = "webp"
+	GIF  ImageFormat = "gif"
+	AVIF ImageFormat = "avif"
+)
+
+// ImageFit is the resizing behavior of the Images API
+type ImageFit string
+
+// Resizing behaviors supported by the Images API
+const (
+	// FitPad resizes the image to the specified dimensions, padding the image if needed
+	FitPad ImageFit = "pad"
+	// FitFill resizes the image to the specified dimensions, cropping the image if needed
+	FitFill ImageFit = "fill"
+	// FitScale resizes the image to the specified dimensions, changing the original aspect ratio if needed
+	FitScale ImageFit = "scale"
+	// FitCrop crops a part of the original image to fit into the specified dimensions
+	FitCrop ImageFit = "crop"
+	// FitThumb creates a thumbnail from the image
+	FitThumb ImageFit = "thumb"
+)
+
+// ImageFocus is the focus area when cropping or resizing an image with the Images API
+type ImageFocus string
+
+// Focus areas supported by the Images API
+const (
+	FocusCenter      ImageFocus = "center"
+	FocusTop         ImageFocus = "top"
+	FocusRight       ImageFocus = "right"
+	FocusLeft        ImageFocus = "left"
+	FocusBottom      ImageFocus = "bottom"
+	FocusTopRight    ImageFocus = "top_right"
+	FocusTopLeft     ImageFocus = "top_left"
+	FocusBottomRight ImageFocus = "bottom_right"
+	FocusBottomLeft  ImageFocus = "bottom_left"
+	// FocusFace focuses on the largest face detected in the image
+	FocusFace ImageFocus = "face"
+	// FocusFaces focuses on all the faces detected in the image
+	FocusFaces ImageFocus = "faces"
+)
+
+const maxImageSize = 4000
+
+var (
+	// ErrNotAnImage is returned by ImageURL.URL if the file is not an image
+	ErrNotAnImage = errors.New("contentful: file is not an image")
+
+	backgroundColor = regexp.MustCompile("^#?([0-9a-fA-F]{6})$")
+)
+
+// ImageURL builds URLs for transforming images with the Images API
+// (https://www.contentful.com/developers/docs/references/images-api/). Create one with File.Image, e.g.
+//   src, err := asset.File.Image().Width(800).Format(contentful.WebP).Quality(70).URL()
+//
+// Invalid combinations of the parameters are reported by URL and SrcSet
+type ImageURL struct {
+	file        File
+	scheme      string
+	width       int
+	height      int
+	format      ImageFormat
+	quality     int
+	fit         ImageFit
+	focus       ImageFocus
+	radius      int
+	circle      bool
+	background  string
+	progressive bool
+	png8        bool
+}
+
+// Image returns a builder for the Images API URL of the file
+func (f File) Image() ImageURL {
+	return ImageURL{file: f}
+}
+
+// Scheme of the URL, e.g. "https". By default the URL is protocol relative like File.URL
+func (i ImageURL) Scheme(scheme string) ImageURL {
+	i.scheme = scheme
+	return i
+}
+
+// Width of the image in pixels, maximum 4000
+func (i ImageURL) Width(width int) ImageURL {
+	i.width = width
+	return i
+}
+
+// Height of the image in pixels, maximum 4000
+func (i ImageURL) Height(height int) ImageURL {
+	i.height = height
+	return i
+}
+
+// Format the image is converted to
+func (i ImageURL) Format(format ImageFormat) ImageURL {
+	i.format = format
+	return i
+}
+
+// Quality of the image between 1 and 100. Not supported for GIF and 8-bit PNG images
+func (i ImageURL) Quality(quality int) ImageURL {
+	i.quality = quality
+	return i
+}
+
+// Fit sets the resizing behavior
+func (i ImageURL) Fit(fit ImageFit) ImageURL {
+	i.fit = fit
+	return i
+}
+
+// Focus sets the focus area. Requires fit FitFill, FitCrop or FitThumb
+func (i ImageURL) Focus(focus ImageFocus) ImageURL {
+	i.focus = focus
+	return i
+}
+
+// Radius rounds the corners of the image by the given radius in pixels
+func (i ImageURL) Radius(radius int) ImageURL {
+	i.radius = radius
+	return i
+}
+
+// Circle crops the image to a circle, or an ellipse if the image is not a square
+func (i ImageURL) Circle() ImageURL {
+	i.circle = true
+	return i
+}
+
+// Background color in hex, e.g. "#ff0000". Requires fit FitPad or rounded corners
+func (i ImageURL) Background(color string) ImageURL {
+	i.background = color
+	return i
+}
+
+// Progressive makes a progressive JPEG. Requires format JPG
+func (i ImageURL) Progressive() ImageURL {
+	i.progressive = true
+	return i
+}
+
+// PNG8 makes an 8-bit PNG. Requires format PNG
+func (i ImageURL) PNG8() ImageURL {
+	i.png8 = true
+	return i
+}
+
+// URL returns the URL of the transformed image or an error if the parameters are not valid
+func (i ImageURL) URL() (string, error) {
+	query, err := i.query()
+	if err != nil {
+		return "", err
+	}
+
+	u := i.file.URL
+	if i.scheme != "" {
+		u = i.file.AbsoluteURL(i.scheme)
+	}
+	if encoded := query.Encode(); encoded != "" {
+		u += "?" + encoded
+	}
+
+	return u, nil
+}
+
+// SrcSet returns a srcset attribute value for responsive images with an image for each width, e.g.
+//   //images.ctfassets.net/image.png?w=400 400w, //images.ctfassets.net/image.png?w=800 800w
+//
+// The width set with Width is ignored. If a height has been set, it is scaled to keep the aspect ratio
+func (i ImageURL) SrcSet(widths ...int) (string, error) {
+	sources := make([]string, len(widths))
+	for index, width := range widths {
+		image := i.Width(width)
+		if i.width > 0 && i.height > 0 {
+			image = image.Height(i.height * width / i.width)
+		}
+
+		u, err := image.URL()
+		if err != nil {
+			return "", err
+		}
+		sources[index] = u + " " + strconv.Itoa(width) + "w"
+	}
+
+	return strings.Join(sources, ", "), nil
+}
+
+func (i ImageURL) query() (url.Values, error) {
+	query := url.Values{}
+
+	if i.file.ContentType != "" && !i.file.IsImage() {
+		return query, ErrNotAnImage
+	}
+
+	err := i.setSize(query)
+	if err != nil {
+		return query, err
+	}
+
+	err = i.setQuality(query)
+	if err != nil {
+		return query, err
+	}
+
+	err = i.setFlags(query)
+	if err != nil {
+		return query, err
+	}
+
+	err = i.setFit(query)
+	if err != nil {
+		return query, err
+	}
+
+	err = i.setCorners(query)
+	if err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// setSize validates and sets the width and height
+func (i ImageURL) setSize(query url.Values) error {
+	if i.width != 0 {
+		if i.width < 0 || i.width > maxImageSize {
+			return fmt.Errorf("contentful: image width must be between 1 and %d, was %d", maxImageSize, i.width)
+		}
+		query.Set("w", strconv.Itoa(i.width))
+	}
+
+	if i.height != 0 {
+		if i.height < 0 || i.height > maxImageSize {
+			return fmt.Errorf("contentful: image height must be between 1 and %d, was %d", maxImageSize, i.height)
+		}
+		query.Set("h", strconv.Itoa(i.height))
+	}
+
+	return nil
+}
+
+// setQuality validates and sets the format and quality
+func (i ImageURL) setQuality(query url.Values) error {
+	if i.format != "" {
+		query.Set("fm", string(i.format))
+	}
+
+	if i.quality != 0 {
+		if i.quality < 0 || i.quality > 100 {
+			return fmt.Errorf("contentful: image quality must be between 1 and 100, was %d", i.quality)
+		}
+		if i.format == GIF || i.png8 {
+			return errors.New("contentful: image quality is not supported for GIF and 8-bit PNG images")
+		}
+		query.Set("q", strconv.Itoa(i.quality))
+	}
+
+	return nil
+}
+
+// setFlags validates and sets the progressive JPEG and 8-bit PNG flags
+func (i ImageURL) setFlags(query url.Values) error {
+	if i.progressive && i.png8 {
+		return errors.New("contentful: image can't be both a progressive JPEG and an 8-bit PNG")
+	}
+	if i.progressive {
+		if i.format != JPG {
+			return errors.New("contentful: progressive image requires JPG format")
+		}
+		query.Set("fl", "progressive")
+	}
+	if i.png8 {
+		if i.format != PNG {
+			return errors.New("contentful: 8-bit PNG image requires PNG format")
+		}
+		query.Set("fl", "png8")
+	}
+
+	return nil
+}
+
+// setFit validates and sets the fit and focus
+func (i ImageURL) setFit(query url.Values) error {
+	if i.fit != "" {
+		query.Set("fit", string(i.fit))
+	}
+
+	if i.focus != "" {
+		if i.fit != FitFill && i.fit != FitCrop && i.fit != FitThumb {
+			return fmt.Errorf("contentful: image focus requires fit %s, %s or %s", FitFill, FitCrop, FitThumb)
+		}
+		query.Set("f", string(i.focus))
+	}
+
+	return nil
+}
+
+// setCorners validates and sets the radius and background color
+func (i ImageURL) setCorners(query url.Values) error {
+	if i.radius < 0 {
+		return fmt.Errorf("contentful: image radius can't be negative, was %d", i.radius)
+	}
+	if i.circle {
+		query.Set("r", "max")
+	} else if i.radius > 0 {
+		query.Set("r", strconv.Itoa(i.radius))
+	}
+
+	if i.background != "" {
+		match := backgroundColor.FindStringSubmatch(i.background)
+		if match == nil {
+			return fmt.Errorf("contentful: image background color must be in hex, e.g. #ff0000, was %s", i.background)
+		}
+		if i.fit != FitPad && i.radius == 0 && !i.circle {
+			return fmt.Errorf("contentful: image background color requires fit %s or rounded corners", FitPad)
+		}
+		query.Set("bg", "rgb:"+strings.ToLower(match[1]))
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package contentful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageURL(t *testing.T) {
+	t.Parallel()
+
+	var (
+		file = File{
+			URL:         "//images.ctfassets.net/space/asset/id/green.png",
+			ContentType: "image/png",
+		}
+		base = file.URL + "?"
+	)
+
+	valid := []struct {
+		name     string
+		image    ImageURL
+		expected string
+	}{
+		{"No parameters", file.Image(), file.URL},
+		{"Scheme", file.Image().Scheme("https"), "https:" + file.URL},
+		{"Size", file.Image().Width(800).Height(600), base + "h=600&w=800"},
+		{
+			"Format, quality, fit and focus",
+			file.Image().Width(800).Format(WebP).Quality(70).Fit(FitFill).Focus(FocusFace),
+			base + "f=face&fit=fill&fm=webp&q=70&w=800",
+		},
+		{"Progressive JPEG", file.Image().Format(JPG).Progressive(), base + "fl=progressive&fm=jpg"},
+		{"8-bit PNG", file.Image().Format(PNG).PNG8(), base + "fl=png8&fm=png"},
+		{"Radius", file.Image().Radius(10), base + "r=10"},
+		{"Circle", file.Image().Circle(), base + "r=max"},
+		{"Background with pad", file.Image().Fit(FitPad).Background("#FF0000"), base + "bg=rgb%3Aff0000&fit=pad"},
+		{"Background with circle", file.Image().Circle().Background("00ff00"), base + "bg=rgb%3A00ff00&r=max"},
+		{"Unknown content type", File{URL: file.URL}.Image().Width(10), base + "w=10"},
+	}
+
+	for _, c := range valid {
+		t.Run(c.name, func(t *testing.T) {
+			u, err := c.image.URL()
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, u)
+		})
+	}
+
+	invalid := []struct {
+		name  string
+		image ImageURL
+	}{
+		{"Not an image", File{URL: file.URL, ContentType: "application/pdf"}.Image()},
+		{"Negative width", file.Image().Width(-1)},
+		{"Too wide", file.Image().Width(4001)},
+		{"Too high", file.Image().Height(4001)},
+		{"Quality too high", file.Image().Quality(101)},
+		{"Quality with GIF", file.Image().Format(GIF).Quality(50)},
+		{"Quality with 8-bit PNG", file.Image().Format(PNG).PNG8().Quality(50)},
+		{"Progressive without JPG", file.Image().Format(PNG).Progressive()},
+		{"Progressive without format", file.Image().Progressive()},
+		{"8-bit PNG without PNG", file.Image().Format(JPG).PNG8()},
+		{"Focus without fit", file.Image().Focus(FocusFace)},
+		{"Focus with scale", file.Image().Fit(FitScale).Focus(FocusTop)},
+		{"Negative radius", file.Image().Radius(-5)},
+		{"Background without pad", file.Image().Background("#ff0000")},
+		{"Invalid background", file.Image().Fit(FitPad).Background("red")},
+	}
+
+	for _, c := range invalid {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.image.URL()
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("Not an image", func(t *testing.T) {
+		_, err := File{ContentType: "video/mp4"}.Image().URL()
+		assert.Equal(t, ErrNotAnImage, err)
+	})
+}
+
+func TestImageURL_SrcSet(t *testing.T) {
+	t.Parallel()
+
+	file := File{URL: "//images.ctfassets.net/green.png", ContentType: "image/png"}
+
+	srcSet, err := file.Image().Format(WebP).SrcSet(400, 800)
+	assert.NoError(t, err)
+	assert.Equal(t, "//images.ctfassets.net/green.png?fm=webp&w=400 400w, //images.ctfassets.net/green.png?fm=webp&w=800 800w", srcSet)
+
+	srcSet, err = file.Image().Width(800).Height(600).Fit(FitFill).SrcSet(400)
+	assert.NoError(t, err)
+	assert.Equal(t, "//images.ctfassets.net/green.png?fit=fill&h=300&w=400 400w", srcSet)
+
+	_, err = file.Image().SrcSet(400, 5000)
+	assert.Error(t, err)
+}
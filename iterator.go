@@ -0,0 +1,151 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"go.opencensus.io/trace"
+)
+
+// ErrIteratorNotStarted is returned by Iterator.Decode if Iterator.Next hasn't returned true
+var ErrIteratorNotStarted = errors.New("contentful: iterator has no current entry")
+
+// Iterator walks through the entries matching the search parameters one entry at a time,
+// fetching the pages lazily. Create one with Contentful.Iterate. Example:
+//   it := cms.Iterate(ctx, contentful.Parameters().ByContentType("product"))
+//   for it.Next(ctx) {
+//     var product Product
+//     if err := it.Decode(&product); err != nil {
+//       return err
+//     }
+//   }
+//   if err := it.Err(); err != nil {
+//     return err
+//   }
+type Iterator struct {
+	cms         *Contentful
+	parameters  SearchParameters
+	environment string
+
+	page    searchResults
+	index   int
+	skip    int
+	fetched int
+	started bool
+	done    bool
+	err     error
+}
+
+// Iterate returns an Iterator over the entries matching the search parameters. The page size can be set
+// with SearchParameters.Limit and defaults to the maximum of 1000. The number of iterated entries can be
+// capped with SearchParameters.MaxItems. The environment is selected from ctx, so all the pages will be
+// fetched from the same environment
+func (cms *Contentful) Iterate(ctx context.Context, parameters SearchParameters) *Iterator {
+	parameters = parameters.clone()
+	if parameters.Get("limit") == "" {
+		parameters = parameters.Limit(maxLimit)
+	}
+	skip, _ := strconv.Atoi(parameters.Get("skip"))
+
+	return &Iterator{
+		cms:         cms,
+		parameters:  parameters,
+		environment: cms.environmentFor(ctx),
+		skip:        skip,
+		index:       -1,
+	}
+}
+
+// Next advances the iterator to the next entry, fetching the next page if needed.
+// Returns false when there are no more entries or an error occurred, see Err
+func (it *Iterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if it.parameters.maxItems > 0 && it.fetched >= it.parameters.maxItems {
+		it.done = true
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.page.Items) {
+		it.fetched++
+		return true
+	}
+
+	if it.started && (len(it.page.Items) == 0 || it.skip >= it.page.Total) {
+		it.done = true
+		return false
+	}
+
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.Iterator.Next")
+	defer span.End()
+
+	page, err := it.cms.search(ContextWithEnvironment(ctx, it.environment), it.parameters.Skip(it.skip))
+	if err != nil {
+		addSpanError(span, trace.StatusCodeUnknown, err)
+		it.err = err
+		return false
+	}
+	appendIncludes(&page)
+	if it.parameters.missingLinks == LinkFetch {
+		err = it.cms.resolveLinks(ContextWithEnvironment(ctx, it.environment), it.parameters.Get("locale"), &page)
+		if err != nil {
+			addSpanError(span, trace.StatusCodeUnknown, err)
+			it.err = err
+			return false
+		}
+	}
+	it.cms.warn(page.Errors)
+
+	it.started = true
+	it.page = page
+	it.index = 0
+	it.skip += len(page.Items)
+
+	if len(page.Items) == 0 {
+		it.done = true
+		return false
+	}
+
+	it.fetched++
+	return true
+}
+
+// Decode marshals the current entry as flattened json into data parameter
+func (it *Iterator) Decode(data interface{}) error {
+	if it.index < 0 || it.index >= len(it.page.Items) {
+		return ErrIteratorNotStarted
+	}
+
+	flattenedItem, err := it.cms.newFlattener(it.page, it.parameters.missingLinks).flattenItem(it.page.Items[it.index])
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(flattenedItem)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, data)
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Total returns the total number of entries matching the search parameters.
+// Will be zero before the first page has been fetched
+func (it *Iterator) Total() int {
+	return it.page.Total
+}
+
+// Offset returns the offset of the current entry in all the entries matching the search parameters
+func (it *Iterator) Offset() int {
+	return it.skip - len(it.page.Items) + it.index
+}
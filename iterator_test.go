@@ -0,0 +1,104 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]item, 5)
+	for i := range entries {
+		entries[i] = item{
+			Sys: itemInfo{Type: linkTypeEntry, ID: strconv.Itoa(i)},
+			Fields: map[string]interface{}{
+				"title": "Page " + strconv.Itoa(i),
+				"banner": map[string]interface{}{
+					"sys": map[string]interface{}{"type": linkType, "linkType": linkTypeAsset, "id": "banner"},
+				},
+			},
+		}
+	}
+
+	var (
+		requests int
+		server   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, "/spaces/spaceID/environments/staging/entries", r.URL.Path)
+			skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+			response := searchResults{Total: len(entries), Skip: skip, Limit: limit}
+			for i := skip; i < skip+limit && i < len(entries); i++ {
+				response.Items = append(response.Items, entries[i])
+			}
+			response.Includes.Asset = []item{{
+				Sys:    itemInfo{Type: linkTypeAsset, ID: "banner"},
+				Fields: map[string]interface{}{"title": "Banner"},
+			}}
+
+			w.WriteHeader(http.StatusOK)
+			err := json.NewEncoder(w).Encode(response)
+			assert.NoError(t, err)
+		}))
+		cms = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		ctx = ContextWithEnvironment(context.Background(), "staging")
+	)
+	defer server.Close()
+
+	type page struct {
+		Title  string `json:"title"`
+		Banner Asset  `json:"banner"`
+		Information
+	}
+
+	t.Run("Iterates over all the pages lazily", func(t *testing.T) {
+		requests = 0
+		it := cms.Iterate(ctx, Parameters().Limit(2))
+		assert.Equal(t, ErrIteratorNotStarted, it.Decode(&page{}))
+		assert.Equal(t, 0, requests)
+
+		var offsets []int
+		for i := 0; it.Next(context.Background()); i++ {
+			var p page
+			err := it.Decode(&p)
+			assert.NoError(t, err)
+			assert.Equal(t, "Page "+strconv.Itoa(i), p.Title)
+			assert.Equal(t, strconv.Itoa(i), p.ID)
+			assert.Equal(t, "Banner", p.Banner.Title)
+			assert.Equal(t, 5, it.Total())
+			assert.Equal(t, i/2+1, requests)
+			offsets = append(offsets, it.Offset())
+		}
+
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, offsets)
+		assert.Equal(t, 3, requests)
+		assert.False(t, it.Next(context.Background()))
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("Starts from skip and stops at max items", func(t *testing.T) {
+		it := cms.Iterate(ctx, Parameters().Skip(1).MaxItems(3))
+		var offsets []int
+		for it.Next(context.Background()) {
+			offsets = append(offsets, it.Offset())
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []int{1, 2, 3}, offsets)
+	})
+
+	t.Run("Stops on error", func(t *testing.T) {
+		it := NewWithOptions("token", "spaceID", WithBaseURL(":")).Iterate(ctx, Parameters())
+		assert.False(t, it.Next(context.Background()))
+		assert.Error(t, it.Err())
+		assert.False(t, it.Next(context.Background()))
+	})
+}
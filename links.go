@@ -0,0 +1,173 @@
+package contentful
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.opencensus.io/trace"
+)
+
+const (
+	// defaultMaxLinkRequests is the default number of additional requests made to resolve the missing links
+	defaultMaxLinkRequests = 10
+	// linkBatchSize is the number of links fetched with one request, which keeps the URL well under its length limit
+	linkBatchSize = 100
+)
+
+// WithMaxLinkRequests sets the maximum number of additional requests made for a single call to fetch the targets
+// of the missing links, when SearchParameters.OnMissingLink is set to LinkFetch. Defaults to 10
+func WithMaxLinkRequests(n int) Option {
+	return func(cms *Contentful) {
+		cms.maxLinkRequests = n
+	}
+}
+
+func (cms *Contentful) linkRequests() int {
+	if cms.maxLinkRequests <= 0 {
+		return defaultMaxLinkRequests
+	}
+	return cms.maxLinkRequests
+}
+
+// resolveLinks fetches the targets of the links, which are not in the response, and appends them to the includes
+// of the response. The links are fetched in batches with "sys.id[in]". The fetched entries can link further,
+// so the links are resolved in rounds until all the targets are found or the maximum number of requests is reached.
+// Links, which couldn't be resolved, are left to the flattener. Links, which Contentful reports as unresolvable,
+// aren't fetched
+func (cms *Contentful) resolveLinks(ctx context.Context, locale string, response *searchResults) error {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.resolveLinks")
+	defer span.End()
+
+	var (
+		requests  int
+		requested = unresolvableLinks(response.Errors)
+		seen      = make(map[string]bool)
+		merged    includes
+	)
+	mergeIncludes(&merged, response.Includes, seen)
+	response.Includes = merged
+	defer func() {
+		span.AddAttributes(trace.Int64Attribute("contentful.link_requests", int64(requests)))
+	}()
+
+	for {
+		entries, assets := missingLinks(*response, seen, requested)
+		if len(entries) == 0 && len(assets) == 0 {
+			return nil
+		}
+
+		for _, batch := range batchLinks(entries, assets) {
+			if requests >= cms.linkRequests() {
+				return nil
+			}
+			requests++
+
+			fetched, err := cms.fetchLinks(ctx, locale, batch)
+			if err != nil {
+				addSpanError(span, trace.StatusCodeUnknown, err)
+				return err
+			}
+			mergeIncludes(&response.Includes, fetched.Includes, seen)
+			response.Errors = mergeErrors(response.Errors, fetched.Errors)
+			for link := range unresolvableLinks(fetched.Errors) {
+				requested[link] = true
+			}
+		}
+	}
+}
+
+// linkBatch is a set of links of the same link type fetched with one request
+type linkBatch struct {
+	linkType string
+	ids      []string
+}
+
+func batchLinks(entries, assets []string) []linkBatch {
+	var batches []linkBatch
+	for _, links := range []linkBatch{{linkTypeEntry, entries}, {linkTypeAsset, assets}} {
+		for start := 0; start < len(links.ids); start += linkBatchSize {
+			end := start + linkBatchSize
+			if end > len(links.ids) {
+				end = len(links.ids)
+			}
+			batches = append(batches, linkBatch{linkType: links.linkType, ids: links.ids[start:end]})
+		}
+	}
+	return batches
+}
+
+// fetchLinks fetches the targets of the links in the batch. The fetched targets are returned in the includes
+// of the results, along with the includes of the fetched entries
+func (cms *Contentful) fetchLinks(ctx context.Context, locale string, batch linkBatch) (searchResults, error) {
+	parameters := Parameters().Limit(len(batch.ids))
+	parameters.Set("sys.id[in]", strings.Join(batch.ids, ","))
+	if locale != "" {
+		parameters = parameters.ByLocale(locale)
+	}
+
+	if batch.linkType == linkTypeAsset {
+		response, err := cms.searchAssets(ctx, parameters)
+		if err != nil {
+			return response, err
+		}
+		response.Includes = includes{Asset: response.Items}
+		return response, nil
+	}
+
+	response, err := cms.search(ctx, parameters)
+	if err != nil {
+		return response, err
+	}
+	appendIncludes(&response)
+	return response, nil
+}
+
+// missingLinks returns the IDs of the entries and the assets, which are linked from the response but are not
+// in seen. The IDs are marked as requested, so each link is fetched only once
+func missingLinks(response searchResults, seen, requested map[string]bool) ([]string, []string) {
+	var entries, assets []string
+	collect := func(sys sys) {
+		key := sys.LinkType + ":" + sys.ID
+		if seen[key] || requested[key] {
+			return
+		}
+		requested[key] = true
+		if sys.LinkType == linkTypeAsset {
+			assets = append(assets, sys.ID)
+		} else {
+			entries = append(entries, sys.ID)
+		}
+	}
+
+	for _, items := range [][]item{response.Items, response.Includes.Entry, response.Includes.Asset} {
+		for _, item := range items {
+			for _, field := range item.Fields {
+				walkLinks(field, collect)
+			}
+		}
+	}
+
+	// Sort for stable requests
+	sort.Strings(entries)
+	sort.Strings(assets)
+	return entries, assets
+}
+
+// walkLinks calls fn for every link in the field
+func walkLinks(field interface{}, fn func(sys sys)) {
+	switch t := field.(type) {
+	case []interface{}:
+		for _, v := range t {
+			walkLinks(v, fn)
+		}
+	case map[string]interface{}:
+		if sys, ok := parseToSys(t["sys"]); ok {
+			fn(sys)
+			return
+		}
+		for _, v := range t {
+			walkLinks(v, fn)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package contentful
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchLinks(t *testing.T) {
+	entries := make([]string, linkBatchSize+1)
+	for i := range entries {
+		entries[i] = strconv.Itoa(i)
+	}
+
+	batches := batchLinks(entries, []string{"asset"})
+	assert.Len(t, batches, 3)
+	assert.Equal(t, linkTypeEntry, batches[0].linkType)
+	assert.Len(t, batches[0].ids, linkBatchSize)
+	assert.Equal(t, []string{strconv.Itoa(linkBatchSize)}, batches[1].ids)
+	assert.Equal(t, linkBatch{linkType: linkTypeAsset, ids: []string{"asset"}}, batches[2])
+
+	assert.Empty(t, batchLinks(nil, nil))
+}
+
+func TestMissingLinks(t *testing.T) {
+	link := func(linkType, id string) map[string]interface{} {
+		return map[string]interface{}{
+			"sys": map[string]interface{}{"type": "Link", "linkType": linkType, "id": id},
+		}
+	}
+	response := searchResults{
+		Items: []item{{
+			Fields: map[string]interface{}{
+				"found":   link(linkTypeEntry, "found"),
+				"entries": []interface{}{link(linkTypeEntry, "b"), link(linkTypeEntry, "a")},
+				"object":  map[string]interface{}{"asset": link(linkTypeAsset, "asset")},
+			},
+		}},
+		Includes: includes{
+			Entry: []item{{
+				Sys:    itemInfo{Type: linkTypeEntry, ID: "found"},
+				Fields: map[string]interface{}{"a": link(linkTypeEntry, "a")},
+			}},
+		},
+	}
+	seen := map[string]bool{linkTypeEntry + ":found": true}
+	requested := make(map[string]bool)
+
+	entries, assets := missingLinks(response, seen, requested)
+	assert.Equal(t, []string{"a", "b"}, entries)
+	assert.Equal(t, []string{"asset"}, assets)
+
+	// Each link is returned only once
+	entries, assets = missingLinks(response, seen, requested)
+	assert.Empty(t, entries)
+	assert.Empty(t, assets)
+}
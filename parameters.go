@@ -3,11 +3,23 @@ package contentful
 import (
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxLimit is the maximum number of entries Contentful returns in one response
+	maxLimit = 1000
+	// maxInclude is the maximum depth of the included references
+	maxInclude = 10
 )
 
 // SearchParameters for GetMany and GetOne functions
 type SearchParameters struct {
 	url.Values
+
+	maxItems     int
+	missingLinks LinkStrategy
 }
 
 // Parameters returns initialized SearchParameters
@@ -29,6 +41,154 @@ func (p SearchParameters) ByFieldValue(fieldName, fieldValue string) SearchParam
 	return p
 }
 
+// ByFieldNotEqual searches entries whose field value is not the given value. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldNotEqual(fieldName, fieldValue string) SearchParameters {
+	return p.byFieldOperator(fieldName, "ne", fieldValue)
+}
+
+// ByFieldIn searches entries whose field value is one of the given values. Values can't contain commas.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldIn(fieldName string, fieldValues ...string) SearchParameters {
+	return p.byFieldOperator(fieldName, "in", strings.Join(fieldValues, ","))
+}
+
+// ByFieldNotIn searches entries whose field value is none of the given values. Values can't contain commas.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldNotIn(fieldName string, fieldValues ...string) SearchParameters {
+	return p.byFieldOperator(fieldName, "nin", strings.Join(fieldValues, ","))
+}
+
+// ByFieldAll searches entries whose array field contains all the given values. Values can't contain commas.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldAll(fieldName string, fieldValues ...string) SearchParameters {
+	return p.byFieldOperator(fieldName, "all", strings.Join(fieldValues, ","))
+}
+
+// ByFieldExists searches entries which have or don't have a value for the field. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldExists(fieldName string, exists bool) SearchParameters {
+	return p.byFieldOperator(fieldName, "exists", strconv.FormatBool(exists))
+}
+
+// ByFieldLessThan searches entries whose number field is less than the value. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldLessThan(fieldName string, value float64) SearchParameters {
+	return p.byFieldOperator(fieldName, "lt", formatNumber(value))
+}
+
+// ByFieldLessThanOrEqual searches entries whose number field is less than or equal to the value.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldLessThanOrEqual(fieldName string, value float64) SearchParameters {
+	return p.byFieldOperator(fieldName, "lte", formatNumber(value))
+}
+
+// ByFieldGreaterThan searches entries whose number field is greater than the value. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldGreaterThan(fieldName string, value float64) SearchParameters {
+	return p.byFieldOperator(fieldName, "gt", formatNumber(value))
+}
+
+// ByFieldGreaterThanOrEqual searches entries whose number field is greater than or equal to the value.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldGreaterThanOrEqual(fieldName string, value float64) SearchParameters {
+	return p.byFieldOperator(fieldName, "gte", formatNumber(value))
+}
+
+// ByFieldBefore searches entries whose date field is before the time. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldBefore(fieldName string, t time.Time) SearchParameters {
+	return p.byFieldOperator(fieldName, "lt", formatTime(t))
+}
+
+// ByFieldBeforeOrAt searches entries whose date field is before or at the time. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldBeforeOrAt(fieldName string, t time.Time) SearchParameters {
+	return p.byFieldOperator(fieldName, "lte", formatTime(t))
+}
+
+// ByFieldAfter searches entries whose date field is after the time. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldAfter(fieldName string, t time.Time) SearchParameters {
+	return p.byFieldOperator(fieldName, "gt", formatTime(t))
+}
+
+// ByFieldAfterOrAt searches entries whose date field is after or at the time. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldAfterOrAt(fieldName string, t time.Time) SearchParameters {
+	return p.byFieldOperator(fieldName, "gte", formatTime(t))
+}
+
+// ByFieldMatch does a full-text search on the field. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByFieldMatch(fieldName, text string) SearchParameters {
+	return p.byFieldOperator(fieldName, "match", text)
+}
+
+// Near orders the entries by the distance of their location field to the location, closest first.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) Near(fieldName string, location Location) SearchParameters {
+	return p.byFieldOperator(fieldName, "near", formatLocations(location))
+}
+
+// WithinCircle searches entries whose location field is within the radius in kilometers from the location.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) WithinCircle(fieldName string, location Location, radiusKm float64) SearchParameters {
+	return p.byFieldOperator(fieldName, "within", formatLocations(location)+","+formatNumber(radiusKm))
+}
+
+// WithinRectangle searches entries whose location field is within the rectangle defined by its south-west
+// (bottom left) and north-east (top right) corners. REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) WithinRectangle(fieldName string, southWest, northEast Location) SearchParameters {
+	return p.byFieldOperator(fieldName, "within", formatLocations(southWest, northEast))
+}
+
+// ByReferenceContentType searches entries whose reference field links to an entry of the content type.
+// REQUIRES THAT CONTENT TYPE IS SET
+func (p SearchParameters) ByReferenceContentType(fieldName, contentType string) SearchParameters {
+	p.Set("fields."+fieldName+".sys.contentType.sys.id", contentType)
+	return p
+}
+
+// ByReferenceFieldValue searches entries whose reference field links to an entry, which has the field value.
+// For example searching articles by the name of their author:
+//   contentful.Parameters().
+//     ByContentType("article").
+//     ByReferenceContentType("author", "person").
+//     ByReferenceFieldValue("author", "name", "Ann")
+//
+// REQUIRES THAT CONTENT TYPE AND THE CONTENT TYPE OF THE REFERENCE ARE SET, see ByReferenceContentType
+func (p SearchParameters) ByReferenceFieldValue(fieldName, referenceFieldName, fieldValue string) SearchParameters {
+	p.Add("fields."+fieldName+".fields."+referenceFieldName, fieldValue)
+	return p
+}
+
+// LinksToEntry searches entries which link to the entry with the given ID, e.g. pages using a banner entry
+func (p SearchParameters) LinksToEntry(entryID string) SearchParameters {
+	p.Set("links_to_entry", entryID)
+	return p
+}
+
+// LinksToAsset searches entries which link to the asset with the given ID
+func (p SearchParameters) LinksToAsset(assetID string) SearchParameters {
+	p.Set("links_to_asset", assetID)
+	return p
+}
+
+// Query does a full-text search on all the text and symbol fields
+func (p SearchParameters) Query(text string) SearchParameters {
+	p.Set("query", text)
+	return p
+}
+
+// OrderBy orders the results by the given attribute, e.g. "sys.createdAt" or "fields.title", in ascending
+// or descending order. Can be called multiple times to order by multiple attributes, the first call having
+// the highest priority
+func (p SearchParameters) OrderBy(attribute string, descending bool) SearchParameters {
+	if descending {
+		attribute = "-" + attribute
+	}
+	return p.appendList("order", attribute)
+}
+
+// Select returns only the given attributes, e.g. "fields.title" or "sys.id", of the entries instead of the whole
+// entries. Can be called multiple times. Selecting fields REQUIRES THAT CONTENT TYPE IS SET. Without "sys" or
+// "sys.id", the returned entries won't have Information and they can't be referenced by the other entries
+func (p SearchParameters) Select(attributes ...string) SearchParameters {
+	return p.appendList("select", attributes...)
+}
+
 // Limit the returned results from Contentful
 func (p SearchParameters) Limit(limit int) SearchParameters {
 	p.Set("limit", strconv.Itoa(limit))
@@ -41,14 +201,82 @@ func (p SearchParameters) Skip(limit int) SearchParameters {
 	return p
 }
 
+// MaxItems caps the number of entries fetched by GetAll. Zero means no cap.
+// This is not sent to Contentful, so remember to use the returned value
+func (p SearchParameters) MaxItems(maxItems int) SearchParameters {
+	p.maxItems = maxItems
+	return p
+}
+
+// Include sets the depth of the references included in the response, between 0 and 10. Defaults to 10.
+// Links deeper than the depth are handled as defined with OnMissingLink
+func (p SearchParameters) Include(depth int) SearchParameters {
+	p.Set("include", strconv.Itoa(depth))
+	return p
+}
+
+// OnMissingLink defines what to do when a link's target is not in the response. Defaults to LinkFail.
+// This is not sent to Contentful, so remember to use the returned value
+func (p SearchParameters) OnMissingLink(strategy LinkStrategy) SearchParameters {
+	p.missingLinks = strategy
+	return p
+}
+
 // ByLocale searches by the given locale
 func (p SearchParameters) ByLocale(locale string) SearchParameters {
 	p.Set("locale", locale)
 	return p
 }
 
+// ByMimeTypeGroup searches assets by the mime type group of the file, e.g. "image", "video" or "pdfdocument"
+func (p SearchParameters) ByMimeTypeGroup(group string) SearchParameters {
+	p.Set("mimetype_group", group)
+	return p
+}
+
 //ByID searches a specific contentful entry by it's "sys.id" parameter
 func (p SearchParameters) ByID(contentfulID string) SearchParameters {
 	p.Set("sys.id", contentfulID)
 	return p
 }
+
+// appendList appends the values to the comma separated list of the key
+func (p SearchParameters) appendList(key string, values ...string) SearchParameters {
+	list := values
+	if existing := p.Get(key); existing != "" {
+		list = append(strings.Split(existing, ","), values...)
+	}
+	p.Set(key, strings.Join(list, ","))
+	return p
+}
+
+func (p SearchParameters) byFieldOperator(fieldName, operator, value string) SearchParameters {
+	p.Set("fields."+fieldName+"["+operator+"]", value)
+	return p
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatLocations(locations ...Location) string {
+	values := make([]string, 0, len(locations)*2)
+	for _, location := range locations {
+		values = append(values, formatNumber(location.Lat), formatNumber(location.Lon))
+	}
+	return strings.Join(values, ",")
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// clone returns a copy of the parameters, which can be modified without affecting the original
+func (p SearchParameters) clone() SearchParameters {
+	values := make(url.Values, len(p.Values))
+	for key, value := range p.Values {
+		values[key] = append([]string(nil), value...)
+	}
+	p.Values = values
+	return p
+}
@@ -1,7 +1,9 @@
 package contentful
 
 import (
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -25,3 +27,105 @@ func TestParameters(t *testing.T) {
 		params.Encode(),
 	)
 }
+
+func TestParametersMaxItems(t *testing.T) {
+	params := Parameters().Limit(10).MaxItems(25)
+	assert.Equal(t, 25, params.maxItems)
+	assert.Equal(t, "limit=10", params.Encode())
+
+	clone := params.clone()
+	clone.Skip(10)
+	assert.Equal(t, 25, clone.maxItems)
+	assert.Equal(t, "limit=10", params.Encode())
+	assert.Equal(t, "limit=10&skip=10", clone.Encode())
+}
+
+func TestParametersInclude(t *testing.T) {
+	params := Parameters().Include(2).OnMissingLink(LinkNull)
+	assert.Equal(t, "include=2", params.Encode())
+	assert.Equal(t, LinkNull, params.missingLinks)
+	assert.Equal(t, LinkNull, params.clone().missingLinks)
+	assert.Equal(t, LinkFail, Parameters().missingLinks)
+}
+
+func TestParametersOperators(t *testing.T) {
+	date := time.Date(2018, 2, 20, 20, 15, 9, 0, time.FixedZone("EET", 2*60*60))
+
+	cases := []struct {
+		params   SearchParameters
+		expected string
+	}{
+		{Parameters().ByFieldNotEqual("title", "Main page"), "fields.title[ne]=Main page"},
+		{Parameters().ByFieldIn("tags", "news", "blog"), "fields.tags[in]=news,blog"},
+		{Parameters().ByFieldNotIn("tags", "news", "blog"), "fields.tags[nin]=news,blog"},
+		{Parameters().ByFieldAll("tags", "news", "blog"), "fields.tags[all]=news,blog"},
+		{Parameters().ByFieldExists("banner", true), "fields.banner[exists]=true"},
+		{Parameters().ByFieldExists("banner", false), "fields.banner[exists]=false"},
+		{Parameters().ByFieldLessThan("price", 10.5), "fields.price[lt]=10.5"},
+		{Parameters().ByFieldLessThanOrEqual("price", 10), "fields.price[lte]=10"},
+		{Parameters().ByFieldGreaterThan("price", 1000000), "fields.price[gt]=1000000"},
+		{Parameters().ByFieldGreaterThanOrEqual("price", -1), "fields.price[gte]=-1"},
+		{Parameters().ByFieldBefore("published", date), "fields.published[lt]=2018-02-20T18:15:09Z"},
+		{Parameters().ByFieldBeforeOrAt("published", date), "fields.published[lte]=2018-02-20T18:15:09Z"},
+		{Parameters().ByFieldAfter("published", date), "fields.published[gt]=2018-02-20T18:15:09Z"},
+		{Parameters().ByFieldAfterOrAt("published", date), "fields.published[gte]=2018-02-20T18:15:09Z"},
+		{Parameters().ByFieldMatch("title", "main page"), "fields.title[match]=main page"},
+		{Parameters().Query("main page"), "query=main page"},
+		{Parameters().ByFieldLessThan("price", 10).ByFieldLessThan("price", 20), "fields.price[lt]=20"},
+	}
+
+	for _, c := range cases {
+		decoded, err := url.QueryUnescape(c.params.Encode())
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, decoded)
+	}
+
+	assert.Equal(t, "fields.tags%5Bin%5D=news%2Cblog", Parameters().ByFieldIn("tags", "news", "blog").Encode())
+}
+
+func TestParametersOrderAndSelect(t *testing.T) {
+	params := Parameters().
+		OrderBy("sys.createdAt", true).
+		OrderBy("fields.title", false).
+		Select("fields.title", "sys.id").
+		Select("fields.subPages")
+
+	assert.Equal(t, "-sys.createdAt,fields.title", params.Get("order"))
+	assert.Equal(t, "fields.title,sys.id,fields.subPages", params.Get("select"))
+}
+
+func TestParametersLocation(t *testing.T) {
+	var (
+		helsinki = Location{Lat: 60.1699, Lon: 24.9384}
+		hanko    = Location{Lat: 59.8236, Lon: 22.9683}
+	)
+
+	cases := []struct {
+		params   SearchParameters
+		expected string
+	}{
+		{Parameters().Near("location", helsinki), "fields.location[near]=60.1699,24.9384"},
+		{Parameters().WithinCircle("location", helsinki, 10.5), "fields.location[within]=60.1699,24.9384,10.5"},
+		{Parameters().WithinRectangle("location", hanko, helsinki), "fields.location[within]=59.8236,22.9683,60.1699,24.9384"},
+	}
+
+	for _, c := range cases {
+		decoded, err := url.QueryUnescape(c.params.Encode())
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, decoded)
+	}
+}
+
+func TestParametersReferences(t *testing.T) {
+	params := Parameters().
+		ByContentType("article").
+		ByReferenceContentType("author", "person").
+		ByReferenceFieldValue("author", "name", "Ann")
+
+	decoded, err := url.QueryUnescape(params.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, "content_type=article&fields.author.fields.name=Ann&fields.author.sys.contentType.sys.id=person", decoded)
+
+	assert.Equal(t, "links_to_entry=entryID", Parameters().LinksToEntry("entryID").Encode())
+	assert.Equal(t, "links_to_asset=assetID", Parameters().LinksToAsset("assetID").Encode())
+}
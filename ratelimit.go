@@ -0,0 +1,157 @@
+package contentful
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the requests of the client to the given number per second, making concurrent callers wait
+// before sending the requests. Contentful's limit for the CDA is 55 requests per second.
+// The client waits also for the rate limit reset, when Contentful rate limits a request. Disabled by default.
+// The limit must be positive: zero or a negative value doesn't block the requests but disables the limiter
+func WithRateLimit(perSecond float64) Option {
+	return func(cms *Contentful) {
+		cms.limiter = newRateLimiter(perSecond, false)
+	}
+}
+
+// WithAdaptiveRateLimit limits the requests of the client like WithRateLimit, starting from the given number per
+// second. The limit is then adapted from the X-Contentful-RateLimit-Second-Limit and
+// X-Contentful-RateLimit-Second-Remaining headers of the responses, and the requests are paused until the reset
+// if X-Contentful-RateLimit-Hour-Remaining reaches 0. Like with WithRateLimit, zero or a negative value disables
+// the limiter
+func WithAdaptiveRateLimit(perSecond float64) Option {
+	return func(cms *Contentful) {
+		cms.limiter = newRateLimiter(perSecond, true)
+	}
+}
+
+// rateLimiter is a token bucket shared by the concurrent callers of the client
+type rateLimiter struct {
+	mutex    sync.Mutex
+	adaptive bool
+	// rate of the tokens per second
+	rate  float64
+	burst float64
+	// tokens available, negative when callers are waiting for the tokens
+	tokens float64
+	last   time.Time
+	// paused until Contentful's rate limit resets
+	paused time.Time
+}
+
+// newRateLimiter returns nil, i.e. no limiter, if perSecond is not positive
+func newRateLimiter(perSecond float64, adaptive bool) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	burst := math.Max(1, perSecond)
+	return &rateLimiter{
+		adaptive: adaptive,
+		rate:     perSecond,
+		burst:    burst,
+		tokens:   burst,
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a request can be sent or ctx is done. Fails early if the wait would end after the
+// context's deadline. Returns the time waited
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	wait := l.reserve()
+	if wait <= 0 {
+		return 0, nil
+	}
+	if exceedsDeadline(ctx, wait) {
+		l.cancel()
+		return 0, context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return wait, nil
+	case <-ctx.Done():
+		l.cancel()
+		return wait, ctx.Err()
+	}
+}
+
+// reserve takes a token and returns the wait until the token is available
+func (l *rateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if l.rate > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 && l.rate > 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if paused := l.paused.Sub(now); paused > wait {
+		wait = paused
+	}
+	return wait
+}
+
+// cancel returns the reserved token
+func (l *rateLimiter) cancel() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tokens++
+}
+
+// update adapts the limiter from the rate limit headers of the response
+func (l *rateLimiter) update(resp *http.Response) {
+	if l == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.pause(now, rateLimitReset(resp))
+	}
+	if !l.adaptive {
+		return
+	}
+
+	if limit, ok := headerInt(resp, "X-Contentful-RateLimit-Second-Limit"); ok && limit > 0 {
+		l.rate = float64(limit)
+		l.burst = float64(limit)
+	}
+	if remaining, ok := headerInt(resp, "X-Contentful-RateLimit-Second-Remaining"); ok && float64(remaining) < l.tokens {
+		l.tokens = float64(remaining)
+	}
+	if remaining, ok := headerInt(resp, "X-Contentful-RateLimit-Hour-Remaining"); ok && remaining == 0 {
+		l.pause(now, rateLimitReset(resp))
+	}
+}
+
+func (l *rateLimiter) pause(now time.Time, reset time.Duration) {
+	if until := now.Add(reset); reset > 0 && until.After(l.paused) {
+		l.paused = until
+	}
+}
+
+func headerInt(resp *http.Response, header string) (int, bool) {
+	value, err := strconv.Atoi(resp.Header.Get(header))
+	return value, err == nil
+}
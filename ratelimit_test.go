@@ -0,0 +1,177 @@
+package contentful
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nil limiter doesn't wait", func(t *testing.T) {
+		var limiter *rateLimiter
+		wait, err := limiter.wait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+		limiter.update(&http.Response{})
+	})
+
+	t.Run("Waits after the burst", func(t *testing.T) {
+		limiter := newRateLimiter(10, false)
+		for i := 0; i < 10; i++ {
+			wait, err := limiter.wait(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, time.Duration(0), wait)
+		}
+
+		start := time.Now()
+		_, err := limiter.wait(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	})
+
+	t.Run("Fails early if the wait is after the deadline", func(t *testing.T) {
+		limiter := newRateLimiter(1, false)
+		_, err := limiter.wait(context.Background())
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err = limiter.wait(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.InDelta(t, 0, limiter.tokens, 0.1)
+	})
+
+	t.Run("Returns the token if the context is cancelled", func(t *testing.T) {
+		limiter := newRateLimiter(1, false)
+		_, err := limiter.wait(context.Background())
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = limiter.wait(ctx)
+		assert.Equal(t, context.Canceled, err)
+		assert.True(t, limiter.tokens >= 0)
+	})
+}
+
+func TestWithRateLimit(t *testing.T) {
+	assert.NotNil(t, NewWithOptions("token", "spaceID", WithRateLimit(10)).limiter)
+	assert.NotNil(t, NewWithOptions("token", "spaceID", WithAdaptiveRateLimit(10)).limiter)
+
+	// Non-positive limits disable the limiter instead of blocking all the requests
+	for _, option := range []Option{WithRateLimit(0), WithRateLimit(-1), WithAdaptiveRateLimit(0)} {
+		cms := NewWithOptions("token", "spaceID", WithRateLimit(10), option)
+		assert.Nil(t, cms.limiter)
+
+		for i := 0; i < 100; i++ {
+			wait, err := cms.limiter.wait(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, time.Duration(0), wait)
+		}
+	}
+}
+
+func TestRateLimiter_update(t *testing.T) {
+	t.Parallel()
+
+	newResponse := func(statusCode int, headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: statusCode, Header: make(http.Header)}
+		for key, value := range headers {
+			resp.Header.Set(key, value)
+		}
+		return resp
+	}
+
+	t.Run("Pauses when rate limited", func(t *testing.T) {
+		limiter := newRateLimiter(100, false)
+		limiter.update(newResponse(http.StatusTooManyRequests, map[string]string{
+			"X-Contentful-RateLimit-Reset": "2",
+		}))
+		assert.True(t, limiter.reserve() > time.Second)
+	})
+
+	t.Run("Ignores the limit headers if not adaptive", func(t *testing.T) {
+		limiter := newRateLimiter(100, false)
+		limiter.update(newResponse(http.StatusOK, map[string]string{
+			"X-Contentful-RateLimit-Second-Limit":     "10",
+			"X-Contentful-RateLimit-Second-Remaining": "0",
+		}))
+		assert.Equal(t, float64(100), limiter.rate)
+		assert.Equal(t, float64(100), limiter.tokens)
+	})
+
+	t.Run("Adapts to the limit headers", func(t *testing.T) {
+		limiter := newRateLimiter(100, true)
+		limiter.update(newResponse(http.StatusOK, map[string]string{
+			"X-Contentful-RateLimit-Second-Limit":     "10",
+			"X-Contentful-RateLimit-Second-Remaining": "2",
+		}))
+		assert.Equal(t, float64(10), limiter.rate)
+		assert.Equal(t, float64(10), limiter.burst)
+		assert.Equal(t, float64(2), limiter.tokens)
+	})
+
+	t.Run("Pauses when the hourly limit is reached", func(t *testing.T) {
+		limiter := newRateLimiter(100, true)
+		limiter.update(newResponse(http.StatusOK, map[string]string{
+			"X-Contentful-RateLimit-Hour-Remaining": "0",
+			"X-Contentful-RateLimit-Reset":          "60",
+		}))
+		assert.True(t, limiter.reserve() > 59*time.Second)
+	})
+}
+
+func TestContentful_rateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"total": 0, "items": []}`))
+	}))
+	defer server.Close()
+
+	var (
+		cms   = NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithRateLimit(20))
+		wg    sync.WaitGroup
+		start = time.Now()
+	)
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := cms.search(context.Background(), Parameters().Skip(i))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// 20 requests are sent immediately, the rest 10 during the next half a second
+	assert.True(t, time.Since(start) >= 400*time.Millisecond)
+}
+
+func TestContentful_rateLimitDeadline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"total": 0, "items": []}`))
+	}))
+	defer server.Close()
+
+	cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithRateLimit(1))
+	_, err := cms.search(context.Background(), Parameters())
+	assert.NoError(t, err)
+
+	// The next token is available after a second, so the request fails without waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = cms.search(ctx, Parameters())
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < 100*time.Millisecond)
+}
@@ -0,0 +1,118 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy defines how the failed requests are retried. Requests are retried if Contentful rate limits them,
+// responds with 500, 502, 503 or 504, or if the request fails with a transient network error.
+// Rate limited requests wait for the time in the X-Contentful-RateLimit-Reset header, others back off exponentially.
+// If the wait would be longer than MaxBackoff or end after the context's deadline, the request fails early.
+// Zero values are replaced with the values of DefaultRetryPolicy
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one. Use 1 to disable the retries
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry. Doubled for each following retry
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait between the retries
+	MaxBackoff time.Duration
+	// Jitter is the fraction between 0 and 1, by which the wait is randomly shortened,
+	// so that concurrent clients don't retry at the same time. Use a negative value to disable the jitter
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the policy used when no policy is set with WithRetryPolicy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying the failed requests. Defaults to DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cms *Contentful) {
+		cms.retryPolicy = &policy
+	}
+}
+
+func (cms *Contentful) retry() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	if cms.retryPolicy == nil {
+		return policy
+	}
+
+	if cms.retryPolicy.MaxAttempts > 0 {
+		policy.MaxAttempts = cms.retryPolicy.MaxAttempts
+	}
+	if cms.retryPolicy.MinBackoff > 0 {
+		policy.MinBackoff = cms.retryPolicy.MinBackoff
+	}
+	if cms.retryPolicy.MaxBackoff > 0 {
+		policy.MaxBackoff = cms.retryPolicy.MaxBackoff
+	}
+	if cms.retryPolicy.Jitter != 0 {
+		policy.Jitter = cms.retryPolicy.Jitter
+	}
+	return policy
+}
+
+// backoff returns the wait before the retry following the given attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := float64(p.MinBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		wait -= wait * math.Min(p.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(wait)
+}
+
+// retryableStatus returns true for the status codes of transient server errors
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransient returns true for the network errors, which might not happen again
+func isTransient(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// rateLimitReset returns the wait from X-Contentful-RateLimit-Reset header, or -1 if the header is not set
+func rateLimitReset(resp *http.Response) time.Duration {
+	if resp == nil {
+		return -1
+	}
+	seconds, err := strconv.Atoi(resp.Header.Get("X-Contentful-RateLimit-Reset"))
+	if err != nil || seconds < 0 {
+		return -1
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// exceedsDeadline returns true if waiting for the given duration would end after the context's deadline
+func exceedsDeadline(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Now().Add(wait).After(deadline)
+}
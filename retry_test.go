@@ -0,0 +1,244 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 4*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(4))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := policy.backoff(2)
+		assert.True(t, wait > time.Second && wait <= 2*time.Second, wait.String())
+	}
+}
+
+func TestContentful_retryPolicy(t *testing.T) {
+	assert.Equal(t, DefaultRetryPolicy(), NewWithOptions("token", "spaceID").retry())
+
+	cms := NewWithOptions("token", "spaceID", WithRetryPolicy(RetryPolicy{MinBackoff: time.Second}))
+	assert.Equal(t, RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 30 * time.Second, Jitter: 0.5}, cms.retry())
+
+	cms = NewWithOptions("token", "spaceID", WithRetryPolicy(RetryPolicy{MaxAttempts: 1, Jitter: -1}))
+	assert.Equal(t, RetryPolicy{MaxAttempts: 1, MinBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second, Jitter: -1}, cms.retry())
+	assert.Equal(t, time.Second, cms.retry().backoff(2))
+}
+
+func TestRetryableStatus(t *testing.T) {
+	for _, statusCode := range []int{500, 502, 503, 504} {
+		assert.True(t, retryableStatus(statusCode))
+	}
+	for _, statusCode := range []int{200, 400, 401, 404, 501} {
+		assert.False(t, retryableStatus(statusCode))
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return false }
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, isTransient(&url.Error{Op: "Get", URL: "url", Err: io.EOF}))
+	assert.True(t, isTransient(&url.Error{Op: "Get", URL: "url", Err: timeoutError{}}))
+	assert.True(t, isTransient(&url.Error{Op: "Get", URL: "url", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}))
+	assert.True(t, isTransient(&url.Error{Op: "Get", URL: "url", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}))
+	assert.False(t, isTransient(&url.Error{Op: "Get", URL: "url", Err: errors.New("unsupported protocol scheme")}))
+	assert.False(t, isTransient(errors.New("error")))
+}
+
+func TestRateLimitReset(t *testing.T) {
+	assert.Equal(t, time.Duration(-1), rateLimitReset(nil))
+
+	resp := &http.Response{
+		Header: make(http.Header),
+	}
+	assert.Equal(t, time.Duration(-1), rateLimitReset(resp))
+
+	resp.Header.Set("X-Contentful-RateLimit-Reset", "foo")
+	assert.Equal(t, time.Duration(-1), rateLimitReset(resp))
+
+	resp.Header.Set("X-Contentful-RateLimit-Reset", "5")
+	assert.Equal(t, 5*time.Second, rateLimitReset(resp))
+}
+
+func TestExceedsDeadline(t *testing.T) {
+	assert.False(t, exceedsDeadline(context.Background(), time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.False(t, exceedsDeadline(ctx, time.Second))
+	assert.True(t, exceedsDeadline(ctx, 10*time.Second))
+}
+
+func TestContentful_retry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		policy = WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+		ctx    = context.Background()
+	)
+
+	newServer := func(attempts *int32, fail func(w http.ResponseWriter, attempt int32) bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempt := atomic.AddInt32(attempts, 1)
+			if fail(w, attempt) {
+				return
+			}
+			_, _ = w.Write([]byte(`{"total": 0, "items": []}`))
+		}))
+	}
+
+	t.Run("Retries server errors", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			if attempt < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return true
+			}
+			return false
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), policy)
+		_, err := cms.search(ctx, Parameters())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("Returns the last error after max attempts", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			w.WriteHeader(http.StatusBadGateway)
+			return true
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), policy)
+		_, err := cms.search(ctx, Parameters())
+		apiErr, ok := err.(*APIError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		}
+		assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("Doesn't retry client errors", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			w.WriteHeader(http.StatusNotFound)
+			return true
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), policy)
+		_, err := cms.search(ctx, Parameters())
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("Retries rate limits without a deadline", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			if attempt == 1 {
+				w.Header().Set("X-Contentful-RateLimit-Reset", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return true
+			}
+			return false
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), policy)
+		_, err := cms.search(ctx, Parameters())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("Fails early if the rate limit reset is after the max backoff", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			w.Header().Set("X-Contentful-RateLimit-Reset", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return true
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), policy)
+		_, err := cms.search(ctx, Parameters())
+		assert.True(t, errors.Is(err, ErrTooManyRequests))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("Fails early if the deadline is before the rate limit reset", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			w.Header().Set("X-Contentful-RateLimit-Reset", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return true
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), policy)
+		c, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		start := time.Now()
+		_, err := cms.search(c, Parameters())
+		assert.True(t, errors.Is(err, ErrTooManyRequests))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+		assert.True(t, time.Since(start) < time.Second)
+	})
+
+	t.Run("Retries transient network errors", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			if attempt == 1 {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				assert.NoError(t, err)
+				_ = conn.Close()
+				return true
+			}
+			return false
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), policy)
+		_, err := cms.search(ctx, Parameters())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("Max attempts of 1 disables the retries", func(t *testing.T) {
+		var attempts int32
+		server := newServer(&attempts, func(w http.ResponseWriter, attempt int32) bool {
+			w.WriteHeader(http.StatusInternalServerError)
+			return true
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+		_, err := cms.search(ctx, Parameters())
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+}
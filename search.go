@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -18,16 +17,19 @@ var (
 	ErrNoEntries = errors.New("contentful: no entries returned")
 	// ErrMoreThanOneEntry is returned if there were more than one entry returned
 	ErrMoreThanOneEntry = errors.New("contentful: more then one entry was returned")
-	// ErrTooManyRequests is returned if hit Contentful rate limit and context doesn't have a deadline set
+	// ErrTooManyRequests matches the *APIError returned if Contentful rate limits the request and the retries fail.
+	// Use errors.Is(err, ErrTooManyRequests) or IsRateLimited to check for it
 	ErrTooManyRequests = errors.New("contentful: too many requests")
 )
 
 // GetMany entries from Contentful. The flattened json output will be marshaled into data parameter,
 // which will need to be a slice or an array. Will return an error if zero entries were returned
 //
-// Will retry if Contentful rate limits the request if
-// - context has a deadline/timeout set and
-// - seconds to wait is not after context's deadline/timeout, making this fail early
+// Will return a *ValidationError without sending the request if the parameters are not valid,
+// see SearchParameters.Validate. Will return an *APIError if Contentful responds with an error
+//
+// Will retry if the request fails, e.g. because Contentful rate limits it, as defined with WithRetryPolicy.
+// Fails early if the wait before a retry is after context's deadline/timeout
 func (cms *Contentful) GetMany(ctx context.Context, parameters SearchParameters, data interface{}) error {
 	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.GetMany")
 	defer span.End()
@@ -43,40 +45,17 @@ func (cms *Contentful) GetMany(ctx context.Context, parameters SearchParameters,
 		return ErrNoEntries
 	}
 
-	_, spanParse := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.parse")
-	defer spanParse.End()
-	appendIncludes(&response)
-
-	flattenedItems, err := flattenItems(response.Includes, response.Items)
-	if err != nil {
-		addSpanError(spanParse, trace.StatusCodeUnknown, err)
-		addSpanError(span, trace.StatusCodeUnknown, err)
-		return err
-	}
-
-	bytes, err := json.Marshal(flattenedItems)
-	if err != nil {
-		addSpanError(spanParse, trace.StatusCodeInternal, err)
-		addSpanError(span, trace.StatusCodeInternal, err)
-		return err
-	}
-
-	err = json.Unmarshal(bytes, data)
-	if err != nil {
-		addSpanError(spanParse, trace.StatusCodeInternal, err)
-		addSpanError(span, trace.StatusCodeInternal, err)
-		return err
-	}
-
-	return nil
+	return cms.parse(ctx, span, parameters, response, false, data)
 }
 
 // GetOne entry from Contentful. The flattened json output will be marshaled into data parameter.
 // Will return an error if there is not exactly one entry returned
 //
-// Will retry if Contentful rate limits the request if
-// - context has a deadline/timeout set and
-// - seconds to wait is not after context's deadline/timeout, making this fail early
+// Will return a *ValidationError without sending the request if the parameters are not valid,
+// see SearchParameters.Validate. Will return an *APIError if Contentful responds with an error
+//
+// Will retry if the request fails, e.g. because Contentful rate limits it, as defined with WithRetryPolicy.
+// Fails early if the wait before a retry is after context's deadline/timeout
 func (cms *Contentful) GetOne(ctx context.Context, parameters SearchParameters, data interface{}) error {
 	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.GetOne")
 	defer span.End()
@@ -97,18 +76,101 @@ func (cms *Contentful) GetOne(ctx context.Context, parameters SearchParameters,
 		return ErrMoreThanOneEntry
 	}
 
-	_, spanParse := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.parse")
+	return cms.parse(ctx, span, parameters, response, true, data)
+}
+
+// GetAll entries from Contentful by paginating through the results until all the entries have been fetched.
+// The flattened json output will be marshaled into data parameter, which will need to be a slice or an array.
+// Will return an error if zero entries were returned
+//
+// The page size can be set with SearchParameters.Limit and defaults to the maximum of 1000.
+// The number of fetched entries can be capped with SearchParameters.MaxItems.
+// References are resolved against the includes of all the pages.
+//
+// Will retry if the request fails, see GetMany for details
+func (cms *Contentful) GetAll(ctx context.Context, parameters SearchParameters, data interface{}) error {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.GetAll")
+	defer span.End()
+
+	parameters = parameters.clone()
+	if parameters.Get("limit") == "" {
+		parameters = parameters.Limit(maxLimit)
+	}
+	skip, _ := strconv.Atoi(parameters.Get("skip"))
+
+	var (
+		merged searchResults
+		pages  int64
+		seen   = make(map[string]bool)
+	)
+
+	for {
+		response, err := cms.search(ctx, parameters.Skip(skip))
+		if err != nil {
+			addSpanError(span, trace.StatusCodeUnknown, err)
+			return err
+		}
+		pages++
+
+		merged.Total = response.Total
+		merged.Items = append(merged.Items, response.Items...)
+		mergeIncludes(&merged.Includes, response.Includes, seen)
+		merged.Errors = mergeErrors(merged.Errors, response.Errors)
+
+		skip += len(response.Items)
+		if parameters.maxItems > 0 && len(merged.Items) >= parameters.maxItems {
+			merged.Items = merged.Items[:parameters.maxItems]
+			break
+		}
+		if len(response.Items) == 0 || skip >= response.Total {
+			break
+		}
+	}
+
+	span.AddAttributes(trace.Int64Attribute("contentful.pages", pages))
+
+	if merged.Total == 0 || len(merged.Items) == 0 {
+		addSpanError(span, trace.StatusCodeNotFound, ErrNoEntries)
+		return ErrNoEntries
+	}
+
+	return cms.parse(ctx, span, parameters, merged, false, data)
+}
+
+// parse flattens the search results and marshals them into data. If single is true,
+// only the first item will be marshaled, otherwise all the items will be marshaled as a slice
+func (cms *Contentful) parse(ctx context.Context, span *trace.Span, parameters SearchParameters, response searchResults, single bool, data interface{}) error {
+	ctx, spanParse := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.parse")
 	defer spanParse.End()
 	appendIncludes(&response)
 
-	flattenedItem, err := flattenItem(response.Includes, response.Items[0])
+	if parameters.missingLinks == LinkFetch {
+		err := cms.resolveLinks(ctx, parameters.Get("locale"), &response)
+		if err != nil {
+			addSpanError(spanParse, trace.StatusCodeUnknown, err)
+			addSpanError(span, trace.StatusCodeUnknown, err)
+			return err
+		}
+	}
+	cms.warn(response.Errors)
+
+	var (
+		flattener = cms.newFlattener(response, parameters.missingLinks)
+		flattened interface{}
+		err       error
+	)
+	if single {
+		flattened, err = flattener.flattenItem(response.Items[0])
+	} else {
+		flattened, err = flattener.flattenItems(response.Items)
+	}
 	if err != nil {
 		addSpanError(spanParse, trace.StatusCodeUnknown, err)
 		addSpanError(span, trace.StatusCodeUnknown, err)
 		return err
 	}
 
-	bytes, err := json.Marshal(flattenedItem)
+	bytes, err := json.Marshal(flattened)
 	if err != nil {
 		addSpanError(spanParse, trace.StatusCodeInternal, err)
 		addSpanError(span, trace.StatusCodeInternal, err)
@@ -129,17 +191,91 @@ func (cms *Contentful) search(ctx context.Context, parameters SearchParameters)
 	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.search")
 	defer span.End()
 
-	response := searchResults{}
+	err := parameters.Validate()
+	if err != nil {
+		addSpanError(span, trace.StatusCodeInvalidArgument, err)
+		return searchResults{}, err
+	}
+
+	// The parameters are shared by the concurrent callers, so they are not modified
+	parameters = parameters.clone()
+	if parameters.Get("include") == "" {
+		parameters.Set("include", strconv.Itoa(maxInclude))
+	}
+
+	return cms.getResults(ctx, span, cms.endpoint(ctx, "/entries"), parameters.Values)
+}
+
+func (cms *Contentful) searchAssets(ctx context.Context, parameters SearchParameters) (searchResults, error) {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.searchAssets")
+	defer span.End()
+
 	if parameters.Values == nil {
 		parameters.Values = url.Values{}
 	}
-	parameters.Set("include", "10")
 
-	urlStr := cms.url + "/spaces/" + cms.spaceID + "/entries?" + parameters.Encode()
+	return cms.getResults(ctx, span, cms.endpoint(ctx, "/assets"), parameters.Values)
+}
+
+// getResults returns the search results from the cache, see WithCache, or requests them.
+// Identical concurrent requests are coalesced into one request, whose results are shared by the callers
+func (cms *Contentful) getResults(ctx context.Context, span *trace.Span, endpoint string, query url.Values) (searchResults, error) {
+	// Encode sorts the parameters, so the same parameters in a different order use the same key
+	key := strconv.FormatBool(cms.preview) + " " + endpoint + "?" + query.Encode()
+
+	var response searchResults
+	if bytes, ok := cms.cache.get(key); ok {
+		span.AddAttributes(trace.BoolAttribute("contentful.cache_hit", true))
+		err := json.Unmarshal(bytes, &response)
+		if err != nil {
+			addSpanError(span, trace.StatusCodeInternal, err)
+		}
+		return response, err
+	}
+	if cms.cache != nil {
+		span.AddAttributes(trace.BoolAttribute("contentful.cache_hit", false))
+	}
+
+	response, shared, err := cms.flights.do(ctx, key, func(ctx context.Context) (searchResults, error) {
+		ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.request")
+		defer span.End()
+
+		var (
+			raw      json.RawMessage
+			response searchResults
+		)
+		err := cms.get(ctx, span, endpoint, query, &raw)
+		if err != nil {
+			return response, err
+		}
+
+		err = json.Unmarshal(raw, &response)
+		if err != nil {
+			addSpanError(span, trace.StatusCodeInternal, err)
+			return response, err
+		}
+
+		cms.cache.set(key, raw)
+		return response, nil
+	})
+	span.AddAttributes(trace.BoolAttribute("contentful.coalesced", shared))
+	if err != nil {
+		addSpanError(span, trace.StatusCodeUnknown, err)
+	}
+	return response, err
+}
+
+// get requests the endpoint with the given query and decodes the json response into result.
+// Information about the request and possible errors are added to the span
+func (cms *Contentful) get(ctx context.Context, span *trace.Span, endpoint string, query url.Values, result interface{}) error {
+	urlStr := endpoint
+	if len(query) > 0 {
+		urlStr += "?" + query.Encode()
+	}
 	urlParsed, err := url.Parse(urlStr)
 	if err != nil {
 		addSpanError(span, trace.StatusCodeInternal, err)
-		return response, err
+		return err
 	}
 
 	span.AddAttributes(trace.StringAttribute("http.host", urlParsed.Host))
@@ -147,100 +283,155 @@ func (cms *Contentful) search(ctx context.Context, parameters SearchParameters)
 	span.AddAttributes(trace.StringAttribute("http.path", urlParsed.Path))
 	span.AddAttributes(trace.StringAttribute("http.query", urlParsed.RawQuery))
 
+	policy := cms.retry()
+	for attempt := 1; ; attempt++ {
+		span.AddAttributes(trace.Int64Attribute("contentful.attempts", int64(attempt)))
+
+		err := cms.waitForLimiter(ctx, span)
+		if err != nil {
+			return err
+		}
+
+		retry, wait, err := cms.attempt(ctx, span, urlStr, result)
+		if err == nil || !retry || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		wait, ok := retryWait(ctx, span, policy, attempt, wait, err)
+		if !ok {
+			return err
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			addSpanError(span, trace.StatusCodeCancelled, ctx.Err())
+			return ctx.Err()
+		}
+	}
+}
+
+// waitForLimiter waits until the rate limiter allows sending the request. The wait and possible errors are added
+// to the span
+func (cms *Contentful) waitForLimiter(ctx context.Context, span *trace.Span) error {
+	limited, err := cms.limiter.wait(ctx)
+	if limited > 0 {
+		span.AddAttributes(trace.Int64Attribute("contentful.rate_limit_wait_ms", int64(limited/time.Millisecond)))
+	}
+	if err == context.Canceled {
+		addSpanError(span, trace.StatusCodeCancelled, err)
+		return err
+	}
+	if err != nil {
+		addSpanError(span, trace.StatusCodeDeadlineExceeded, err)
+		return err
+	}
+	return nil
+}
+
+// retryWait returns the wait before retrying the failed attempt, or false if the request should fail early,
+// because the wait is longer than the max backoff or would end after the context's deadline.
+// A negative wait is replaced with the backoff of the policy
+func retryWait(ctx context.Context, span *trace.Span, policy RetryPolicy, attempt int, wait time.Duration, err error) (time.Duration, bool) {
+	if wait < 0 {
+		wait = policy.backoff(attempt)
+	}
+	if wait > policy.MaxBackoff {
+		addSpanError(span, trace.StatusCodeResourceExhausted, err)
+		return wait, false
+	}
+	if exceedsDeadline(ctx, wait) {
+		addSpanError(span, trace.StatusCodeDeadlineExceeded, err)
+		return wait, false
+	}
+	return wait, true
+}
+
+// attempt makes a single request and decodes the json response into result.
+// Returns whether the request can be retried and the wait before the retry, or -1 if the wait isn't known
+func (cms *Contentful) attempt(ctx context.Context, span *trace.Span, urlStr string, result interface{}) (bool, time.Duration, error) {
 	req, err := http.NewRequest(http.MethodGet, urlStr, nil)
 	if err != nil {
 		addSpanError(span, trace.StatusCodeInternal, err)
-		return response, err
+		return false, 0, err
 	}
 
 	req.Header.Add("Authorization", "Bearer "+cms.token)
+	if cms.userAgent != "" {
+		req.Header.Set("User-Agent", cms.userAgent)
+	}
 	req = req.WithContext(ctx)
-	resp, err := http.DefaultClient.Do(req)
-	if err == context.Canceled {
-		addSpanError(span, trace.StatusCodeCancelled, err)
-		return response, err
+	resp, err := cms.httpClient().Do(req)
+	if err != nil && ctx.Err() == context.Canceled {
+		addSpanError(span, trace.StatusCodeCancelled, ctx.Err())
+		return false, 0, ctx.Err()
 	}
-	if err == context.DeadlineExceeded {
-		addSpanError(span, trace.StatusCodeDeadlineExceeded, err)
-		return response, err
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		addSpanError(span, trace.StatusCodeDeadlineExceeded, ctx.Err())
+		return false, 0, ctx.Err()
 	}
 	if err != nil {
 		addSpanError(span, trace.StatusCodeUnknown, err)
-		return response, err
+		return isTransient(err), -1, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
 	span.AddAttributes(trace.Int64Attribute("http.status_code", int64(resp.StatusCode)))
+	cms.limiter.update(resp)
 
-	if resp.StatusCode == http.StatusTooManyRequests {
-		addSpanError(span, trace.StatusCodeResourceExhausted, ErrTooManyRequests)
-		seconds := retryAfter(ctx, resp)
-		if seconds == -1 {
-			addSpanError(span, trace.StatusCodeDeadlineExceeded, ErrTooManyRequests)
-			return response, ErrTooManyRequests
+	if resp.StatusCode != http.StatusOK {
+		apiErr := newAPIError(resp, urlStr)
+		if apiErr.RequestID != "" {
+			span.AddAttributes(trace.StringAttribute("contentful.request_id", apiErr.RequestID))
 		}
-
-		span.AddAttributes(trace.Int64Attribute("http.ratelimit_reset", int64(seconds)))
-
-		select {
-		case <-time.After(time.Second * time.Duration(seconds)):
-			return cms.search(ctx, parameters)
-		case <-ctx.Done():
-			addSpanError(span, trace.StatusCodeCancelled, err)
-			return response, ctx.Err()
+		addSpanError(span, apiErr.spanStatus(), apiErr)
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := rateLimitReset(resp)
+			if wait >= 0 {
+				span.AddAttributes(trace.Int64Attribute("http.ratelimit_reset", int64(wait/time.Second)))
+			}
+			return true, wait, apiErr
 		}
+		return retryableStatus(resp.StatusCode), -1, apiErr
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("non-ok status code: %d", resp.StatusCode)
-		addSpanError(span, trace.StatusCodeUnknown, err)
-		return response, err
-	}
-
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err = json.NewDecoder(resp.Body).Decode(result)
 	if err != nil {
 		addSpanError(span, trace.StatusCodeInternal, err)
-		return response, err
+		return false, 0, err
 	}
 
-	return response, nil
+	return false, 0, nil
 }
 
-func retryAfter(ctx context.Context, resp *http.Response) int {
-	timeUntilCancel, deadlineSet := ctx.Deadline()
-	if !deadlineSet {
-		return -1
-	}
-
-	var (
-		retrySeconds = 2
-		header       string
-	)
-
-	if resp != nil {
-		header = resp.Header.Get("X-Contentful-RateLimit-Reset")
-	}
-
-	s, err := strconv.Atoi(header)
-	if err == nil {
-		retrySeconds = s
+// mergeIncludes appends the entries and assets of src to dst, skipping the ones already added.
+// seen holds the keys of the already added entries and assets
+func mergeIncludes(dst *includes, src includes, seen map[string]bool) {
+	for _, entry := range src.Entry {
+		if key := linkTypeEntry + ":" + entry.Sys.ID; !seen[key] {
+			seen[key] = true
+			dst.Entry = append(dst.Entry, entry)
+		}
 	}
-
-	timeToRetry := time.Now().Add(time.Second * time.Duration(retrySeconds))
-	shouldRetry := timeToRetry.Before(timeUntilCancel)
-	if shouldRetry {
-		return retrySeconds
+	for _, asset := range src.Asset {
+		if key := linkTypeAsset + ":" + asset.Sys.ID; !seen[key] {
+			seen[key] = true
+			dst.Asset = append(dst.Asset, asset)
+		}
 	}
-
-	return -1
 }
 
 // appendIncludes will append current search results to includes object,
 // because Contentful doesn't duplicate items from search results to includes.
+// Items without a type are entries, which have been queried with "select" parameter leaving out "sys.type"
 func appendIncludes(response *searchResults) {
 	for _, item := range response.Items {
+		if item.Sys.Type == "" && item.Sys.ID != "" {
+			item.Sys.Type = linkTypeEntry
+		}
 		if item.Sys.Type == linkTypeEntry {
 			response.Includes.Entry = append(response.Includes.Entry, item)
 		}
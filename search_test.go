@@ -3,9 +3,15 @@ package contentful
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -263,8 +269,9 @@ func TestContentful_search(t *testing.T) {
 
 	var (
 		cms = Contentful{
-			token:   "token",
-			spaceID: "spaceID",
+			token:       "token",
+			spaceID:     "spaceID",
+			retryPolicy: &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond},
 		}
 		ctx = context.Background()
 	)
@@ -329,7 +336,7 @@ func TestContentful_search(t *testing.T) {
 		assert.Equal(t, 2, len(response.Items))
 	})
 
-	t.Run("Should return ErrTooManyRequests if retry shouldn't be tried", func(t *testing.T) {
+	t.Run("Should return ErrTooManyRequests if retries fail", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusTooManyRequests)
 		}))
@@ -337,18 +344,20 @@ func TestContentful_search(t *testing.T) {
 
 		cms.url = server.URL
 
-		t.Run("No deadline set on context", func(t *testing.T) {
+		t.Run("Max attempts reached", func(t *testing.T) {
 			_, err := cms.search(context.Background(), Parameters())
 			assert.Error(t, err)
-			assert.Equal(t, ErrTooManyRequests, err)
+			assert.True(t, errors.Is(err, ErrTooManyRequests))
 		})
 
-		t.Run("Deadline is before the retry seconds", func(t *testing.T) {
+		t.Run("Deadline is before the retry", func(t *testing.T) {
+			cms := cms
+			cms.retryPolicy = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour}
 			c, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 			_, err := cms.search(c, Parameters())
 			assert.Error(t, err)
-			assert.Equal(t, ErrTooManyRequests, err)
+			assert.True(t, errors.Is(err, ErrTooManyRequests))
 		})
 	})
 
@@ -386,88 +395,6 @@ func TestContentful_search(t *testing.T) {
 	})
 }
 
-func TestContentful_retryAfter(t *testing.T) {
-	t.Parallel()
-
-	t.Run("If deadline is not set on context, will always return -1", func(t *testing.T) {
-		ctx := context.TODO()
-		assert.Equal(t, -1, retryAfter(ctx, nil))
-
-		ctx = context.Background()
-		assert.Equal(t, -1, retryAfter(ctx, nil))
-
-		ctx = context.WithValue(context.Background(), struct{}{}, "bar")
-		assert.Equal(t, -1, retryAfter(ctx, nil))
-	})
-
-	t.Run("If deadline is set on context, will not return -1", func(t *testing.T) {
-		ctx1, cancel1 := context.WithDeadline(context.Background(), time.Now().Add(time.Hour*1))
-		defer cancel1()
-		assert.NotEqual(t, -1, retryAfter(ctx1, nil))
-
-		ctx2, cancel2 := context.WithTimeout(context.Background(), time.Hour*1)
-		defer cancel2()
-		assert.NotEqual(t, -1, retryAfter(ctx2, nil))
-	})
-
-	t.Run("If header can't be parsed, returns 2", func(t *testing.T) {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Hour*1)
-		defer cancel()
-		assert.Equal(t, 2, retryAfter(ctx, nil))
-
-		resp := &http.Response{
-			Header: make(http.Header),
-		}
-		assert.Equal(t, 2, retryAfter(ctx, resp))
-
-		resp.Header.Set("X-Contentful-RateLimit-Reset", "foo")
-		assert.Equal(t, 2, retryAfter(ctx, resp))
-
-		resp.Header.Set("X-Contentful-RateLimit-Reset", "5")
-		assert.NotEqual(t, 2, retryAfter(ctx, resp))
-	})
-
-	t.Run("If deadline of context would end before retry, will return -1", func(t *testing.T) {
-		t.Run("Header not ok", func(t *testing.T) {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
-			defer cancel()
-
-			assert.Equal(t, -1, retryAfter(ctx, nil))
-		})
-
-		t.Run("Header ok", func(t *testing.T) {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-			defer cancel()
-
-			resp := &http.Response{
-				Header: make(http.Header),
-			}
-			resp.Header.Set("X-Contentful-RateLimit-Reset", "10")
-			assert.Equal(t, -1, retryAfter(ctx, resp))
-		})
-	})
-
-	t.Run("If deadline of context will end after retry, will return retry seconds", func(t *testing.T) {
-		t.Run("Header not ok", func(t *testing.T) {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-			defer cancel()
-
-			assert.Equal(t, 2, retryAfter(ctx, nil))
-		})
-
-		t.Run("Header not ok", func(t *testing.T) {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-			defer cancel()
-
-			resp := &http.Response{
-				Header: make(http.Header),
-			}
-			resp.Header.Set("X-Contentful-RateLimit-Reset", "5")
-			assert.Equal(t, 5, retryAfter(ctx, resp))
-		})
-	})
-}
-
 func TestAppendIncludes(t *testing.T) {
 	t.Parallel()
 
@@ -508,3 +435,382 @@ func TestAppendIncludes(t *testing.T) {
 	assert.Equal(t, "1", response.Includes.Entry[0].Sys.ID)
 	assert.Equal(t, "2", response.Includes.Entry[1].Sys.ID)
 }
+
+func TestContentful_GetAll(t *testing.T) {
+	t.Parallel()
+
+	// Five entries, each page includes the entry linked from its items
+	entry := func(id string, link string) item {
+		i := item{
+			Sys:    itemInfo{Type: linkTypeEntry, ID: id},
+			Fields: map[string]interface{}{"title": "Page " + id},
+		}
+		if link != "" {
+			i.Fields["related"] = map[string]interface{}{
+				"sys": map[string]interface{}{"type": linkType, "linkType": linkTypeEntry, "id": link},
+			}
+		}
+		return i
+	}
+	entries := []item{entry("1", "5"), entry("2", ""), entry("3", "1"), entry("4", ""), entry("5", "")}
+
+	var (
+		requests []string
+		server   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.RawQuery)
+			skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+			response := searchResults{Total: len(entries), Skip: skip, Limit: limit}
+			for i := skip; i < skip+limit && i < len(entries); i++ {
+				response.Items = append(response.Items, entries[i])
+			}
+			if skip == 0 {
+				response.Includes.Entry = []item{entries[4]}
+			}
+			if skip == 2 {
+				response.Includes.Entry = []item{entries[0]}
+			}
+
+			w.WriteHeader(http.StatusOK)
+			err := json.NewEncoder(w).Encode(response)
+			assert.NoError(t, err)
+		}))
+		cms = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		ctx = context.Background()
+	)
+	defer server.Close()
+
+	type page struct {
+		Title   string `json:"title"`
+		Related *page  `json:"related"`
+	}
+
+	t.Run("Fetches all pages", func(t *testing.T) {
+		requests = nil
+		var result []page
+		err := cms.GetAll(ctx, Parameters().Limit(2), &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(requests))
+		assert.Equal(t, 5, len(result))
+		assert.Equal(t, "Page 5", result[0].Related.Title)
+		assert.Equal(t, "Page 1", result[2].Related.Title)
+		assert.Equal(t, "Page 5", result[4].Title)
+	})
+
+	t.Run("Defaults to the maximum page size", func(t *testing.T) {
+		requests = nil
+		var result []page
+		err := cms.GetAll(ctx, Parameters(), &result)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"include=10&limit=1000&skip=0"}, requests)
+		assert.Equal(t, 5, len(result))
+	})
+
+	t.Run("Caps the number of entries", func(t *testing.T) {
+		requests = nil
+		var result []page
+		err := cms.GetAll(ctx, Parameters().Limit(2).MaxItems(3), &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(requests))
+		assert.Equal(t, 3, len(result))
+		assert.Equal(t, "Page 3", result[2].Title)
+	})
+
+	t.Run("Doesn't modify the parameters", func(t *testing.T) {
+		params := Parameters().Skip(2)
+		var result []page
+		err := cms.GetAll(ctx, params, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(result))
+		assert.Equal(t, "skip=2", params.Encode())
+	})
+
+	t.Run("Returns ErrNoEntries if there are no entries", func(t *testing.T) {
+		var result []page
+		err := cms.GetAll(ctx, Parameters().Skip(10), &result)
+		assert.Equal(t, ErrNoEntries, err)
+	})
+}
+
+func TestContentful_GetSelect(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "fields.title,fields.subPages,sys.id", r.URL.Query().Get("select"))
+			w.WriteHeader(http.StatusOK)
+			bytes, err := ioutil.ReadFile("testdata/select_pages.json")
+			assert.NoError(t, err)
+			_, err = w.Write(bytes)
+			assert.NoError(t, err)
+		}))
+		cms = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+	)
+	defer server.Close()
+
+	type page struct {
+		Title    string `json:"title"`
+		SubPages []page `json:"subPages"`
+		Information
+	}
+
+	var pages []page
+	params := Parameters().ByContentType("page").Select("fields.title", "fields.subPages", "sys.id")
+	err := cms.GetMany(context.Background(), params, &pages)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(pages))
+	assert.Equal(t, "2Cbt07njicqO4wSYCQ8CeK", pages[0].ID)
+	assert.Equal(t, "", pages[0].ContentType)
+	assert.True(t, pages[0].CreatedAt.IsZero())
+	assert.Equal(t, "Sub page", pages[0].SubPages[0].Title)
+	assert.Equal(t, "FcAxxzogmsOMcc0kac6Iu", pages[0].SubPages[0].ID)
+	assert.Equal(t, "", pages[2].ID)
+	assert.Equal(t, "Without sys", pages[2].Title)
+}
+
+func link(linkType, id string) map[string]interface{} {
+	return map[string]interface{}{
+		"sys": map[string]interface{}{"type": "Link", "linkType": linkType, "id": id},
+	}
+}
+
+// linkServer responds to the search with an article of the given fields, and to the requests for the links
+// with the entries and assets of the requested ids. The entry "author" links to the asset "avatar", and the ids
+// "missing" are not found
+type linkServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	requests []string
+	queries  []url.Values
+}
+
+func newLinkServer(t *testing.T, fields map[string]interface{}) *linkServer {
+	s := &linkServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("sys.id[in]")
+		s.mutex.Lock()
+		s.requests = append(s.requests, strings.TrimPrefix(r.URL.Path, "/spaces/spaceID")+" "+ids)
+		s.queries = append(s.queries, r.URL.Query())
+		s.mutex.Unlock()
+
+		response := searchResults{}
+		if ids == "" {
+			response.Items = []item{{Sys: itemInfo{Type: "Entry", ID: "article"}, Fields: fields}}
+		}
+		for _, id := range strings.Split(ids, ",") {
+			switch {
+			case ids == "" || id == "missing":
+			case strings.HasSuffix(r.URL.Path, "/entries"):
+				entryFields := map[string]interface{}{"name": id}
+				if id == "author" {
+					entryFields["avatar"] = link("Asset", "avatar")
+				}
+				response.Items = append(response.Items, item{Sys: itemInfo{Type: "Entry", ID: id}, Fields: entryFields})
+			default:
+				response.Items = append(response.Items, item{
+					Sys:    itemInfo{Type: "Asset", ID: id},
+					Fields: map[string]interface{}{"title": id},
+				})
+			}
+		}
+		response.Total = len(response.Items)
+		err := json.NewEncoder(w).Encode(response)
+		assert.NoError(t, err)
+	}))
+	return s
+}
+
+// reset returns the requests made since the previous reset
+func (s *linkServer) reset() ([]string, []url.Values) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	requests, queries := s.requests, s.queries
+	s.requests, s.queries = nil, nil
+	return requests, queries
+}
+
+func TestContentful_GetMissingLinks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = newLinkServer(t, map[string]interface{}{
+			"author": link("Entry", "author"),
+			"images": []interface{}{link("Asset", "image"), link("Asset", "missing")},
+		})
+		cms = Contentful{
+			token:   "token",
+			spaceID: "spaceID",
+			url:     server.URL,
+		}
+		ctx        = context.Background()
+		parameters = Parameters().Include(1).ByLocale("fi")
+	)
+	defer server.Close()
+
+	t.Run("Fail", func(t *testing.T) {
+		result := make(map[string]interface{})
+		err := cms.GetOne(ctx, parameters, &result)
+		assert.Error(t, err)
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		result := make(map[string]interface{})
+		err := cms.GetOne(ctx, parameters.OnMissingLink(LinkRaw), &result)
+		assert.NoError(t, err)
+		assert.Equal(t, link("Entry", "author"), result["author"])
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		result := make(map[string]interface{})
+		err := cms.GetOne(ctx, parameters.OnMissingLink(LinkNull), &result)
+		assert.NoError(t, err)
+		author, ok := result["author"]
+		assert.True(t, ok)
+		assert.Nil(t, author)
+	})
+
+	t.Run("Fetch", func(t *testing.T) {
+		server.reset()
+		result := make(map[string]interface{})
+		err := cms.GetOne(ctx, parameters.OnMissingLink(LinkFetch), &result)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "id missing")
+
+		// Search, entries and assets of the first round, assets of the second round
+		requests, queries := server.reset()
+		assert.Equal(t, []string{
+			"/entries ",
+			"/entries author",
+			"/assets image,missing",
+			"/assets avatar",
+		}, requests)
+		assert.Equal(t, "1", queries[0].Get("include"))
+		for _, query := range queries {
+			assert.Equal(t, "fi", query.Get("locale"))
+		}
+	})
+
+	t.Run("Fetch bounded with WithMaxLinkRequests", func(t *testing.T) {
+		server.reset()
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithMaxLinkRequests(1))
+		result := make(map[string]interface{})
+		err := cms.GetOne(ctx, parameters.OnMissingLink(LinkFetch), &result)
+		assert.Error(t, err)
+		requests, _ := server.reset()
+		assert.Equal(t, 2, len(requests))
+	})
+}
+
+func TestContentful_GetFetchLinks(t *testing.T) {
+	t.Parallel()
+
+	related := make([]interface{}, linkBatchSize+1)
+	for i := range related {
+		related[i] = link("Entry", fmt.Sprintf("related%03d", i))
+	}
+
+	var (
+		ctx        = context.Background()
+		parameters = Parameters().Include(0).OnMissingLink(LinkFetch)
+	)
+
+	t.Run("Links are resolved", func(t *testing.T) {
+		server := newLinkServer(t, map[string]interface{}{
+			"author": link("Entry", "author"),
+			"images": []interface{}{link("Asset", "image")},
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		result := make(map[string]interface{})
+		err := cms.GetOne(ctx, parameters, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"contentfulId": "author",
+			"name":         "author",
+			"avatar":       map[string]interface{}{"contentfulId": "avatar", "title": "avatar"},
+		}, result["author"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"contentfulId": "image", "title": "image"},
+		}, result["images"])
+
+		requests, _ := server.reset()
+		assert.Equal(t, []string{
+			"/entries ",
+			"/entries author",
+			"/assets image",
+			"/assets avatar",
+		}, requests)
+	})
+
+	t.Run("Links are fetched in batches", func(t *testing.T) {
+		server := newLinkServer(t, map[string]interface{}{
+			"author":  link("Entry", "author"),
+			"related": related,
+		})
+		defer server.Close()
+
+		cms := NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		result := make(map[string]interface{})
+		err := cms.GetOne(ctx, parameters, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, linkBatchSize+1, len(result["related"].([]interface{})))
+
+		// Search, two batches of entries and the assets of the second round
+		requests, _ := server.reset()
+		assert.Equal(t, 4, len(requests))
+		assert.Equal(t, linkBatchSize, len(strings.Split(strings.TrimPrefix(requests[1], "/entries "), ",")))
+		// The author and 101 related entries
+		assert.Equal(t, "/entries related099,related100", requests[2])
+		assert.Equal(t, "/assets avatar", requests[3])
+	})
+}
+
+func TestContentful_GetUnresolvableLinks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "", r.URL.Query().Get("sys.id[in]"))
+			bytes, err := ioutil.ReadFile("testdata/unresolvable_links.json")
+			assert.NoError(t, err)
+			_, err = w.Write(bytes)
+			assert.NoError(t, err)
+		}))
+		mutex    sync.Mutex
+		warnings []Warning
+		cms      = NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithWarningHandler(func(warning Warning) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			warnings = append(warnings, warning)
+		}))
+	)
+	defer server.Close()
+
+	type article struct {
+		Title   string    `json:"title"`
+		Author  *article  `json:"author"`
+		Related []article `json:"related"`
+	}
+
+	for _, strategy := range []LinkStrategy{LinkFail, LinkRaw, LinkNull, LinkFetch} {
+		mutex.Lock()
+		warnings = nil
+		mutex.Unlock()
+
+		var result article
+		err := cms.GetOne(context.Background(), Parameters().OnMissingLink(strategy), &result)
+		assert.NoError(t, err)
+		assert.Equal(t, "Article", result.Title)
+		assert.Nil(t, result.Author)
+		assert.Equal(t, []article{{Title: "Published article"}}, result.Related)
+
+		mutex.Lock()
+		assert.Equal(t, []Warning{
+			{Reason: "notResolvable", LinkType: "Entry", ID: "unpublishedAuthor"},
+			{Reason: "notResolvable", LinkType: "Entry", ID: "deletedArticle"},
+		}, warnings)
+		mutex.Unlock()
+	}
+}
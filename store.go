@@ -0,0 +1,489 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opencensus.io/trace"
+)
+
+// StoreSnapshot is the content of a Store, which is saved and loaded by StorePersistence
+type StoreSnapshot struct {
+	SyncToken string
+	Entries   []SyncEvent
+	Assets    []SyncEvent
+}
+
+// StorePersistence saves and loads the content of a Store
+type StorePersistence interface {
+	// Load returns the latest saved snapshot. Returns an empty snapshot if nothing has been saved
+	Load() (StoreSnapshot, error)
+	// Save the snapshot
+	Save(snapshot StoreSnapshot) error
+}
+
+// Store keeps a local mirror of a space, which is updated with the Sync API. Store can be queried
+// like Contentful.GetMany and Contentful.GetOne, supporting the following parameters:
+//   - content_type
+//   - sys.id
+//   - fields.<field> without search operators, matching exactly the field value or one of the values of an array field
+//   - locale, defaulting to the default locale given to NewStore. Fields without a value for the locale
+//     fall back to the default locale
+//   - skip and limit
+//
+// Store is safe for concurrent use
+type Store struct {
+	cms           *Contentful
+	defaultLocale string
+	persistence   StorePersistence
+
+	// syncMu serializes Sync and Load, while mu guards the content. The content can be read during the requests
+	// of Sync
+	syncMu    sync.Mutex
+	mu        sync.RWMutex
+	syncToken string
+	entries   map[string]SyncEvent
+	assets    map[string]SyncEvent
+}
+
+// NewStore creates a new Store, which is synchronized using the client. References are resolved using
+// defaultLocale when locale parameter isn't given. If persistence is not nil, the content is loaded from it
+// on the first Sync call and saved to it after each Sync call
+func NewStore(cms *Contentful, defaultLocale string, persistence StorePersistence) *Store {
+	return &Store{
+		cms:           cms,
+		defaultLocale: defaultLocale,
+		persistence:   persistence,
+	}
+}
+
+// Sync updates the store with the changes made in the space since the previous sync. On the first call the store
+// is loaded from the persistence, if given. If Contentful can't be reached, the store keeps the loaded content
+// and the error is returned, so it's safe to keep serving the content
+func (s *Store) Sync(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.Store.Sync")
+	defer span.End()
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	s.mu.Lock()
+	if s.entries == nil {
+		err := s.load()
+		if err != nil {
+			s.mu.Unlock()
+			addSpanError(span, trace.StatusCodeInternal, err)
+			return err
+		}
+	}
+	syncToken := s.syncToken
+	s.mu.Unlock()
+
+	// The store is not locked during the requests, so the content can be served while Contentful is unreachable
+	result, err := s.cms.Sync(ctx, syncToken)
+	if err != nil {
+		addSpanError(span, trace.StatusCodeUnknown, err)
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range result.Events {
+		switch event.Type {
+		case SyncEntry:
+			s.entries[event.ID] = event
+		case SyncAsset:
+			s.assets[event.ID] = event
+		case SyncDeletedEntry:
+			delete(s.entries, event.ID)
+		case SyncDeletedAsset:
+			delete(s.assets, event.ID)
+		}
+	}
+	s.syncToken = result.NextSyncToken
+
+	if s.persistence == nil {
+		return nil
+	}
+
+	err = s.persistence.Save(s.snapshot())
+	if err != nil {
+		addSpanError(span, trace.StatusCodeInternal, err)
+		return err
+	}
+
+	return nil
+}
+
+// Load the store from the persistence without synchronizing, e.g. when Contentful can't be reached on startup
+func (s *Store) Load() error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+func (s *Store) load() error {
+	s.entries = make(map[string]SyncEvent)
+	s.assets = make(map[string]SyncEvent)
+	s.syncToken = ""
+
+	if s.persistence == nil {
+		return nil
+	}
+
+	snapshot, err := s.persistence.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range snapshot.Entries {
+		s.entries[entry.ID] = entry
+	}
+	for _, asset := range snapshot.Assets {
+		s.assets[asset.ID] = asset
+	}
+	s.syncToken = snapshot.SyncToken
+
+	return nil
+}
+
+func (s *Store) snapshot() StoreSnapshot {
+	snapshot := StoreSnapshot{
+		SyncToken: s.syncToken,
+		Entries:   make([]SyncEvent, 0, len(s.entries)),
+		Assets:    make([]SyncEvent, 0, len(s.assets)),
+	}
+	for _, entry := range s.entries {
+		snapshot.Entries = append(snapshot.Entries, entry)
+	}
+	for _, asset := range s.assets {
+		snapshot.Assets = append(snapshot.Assets, asset)
+	}
+	sortEvents(snapshot.Entries)
+	sortEvents(snapshot.Assets)
+
+	return snapshot
+}
+
+// GetMany entries from the store. The flattened json output will be marshaled into data parameter,
+// which will need to be a slice or an array. Will return an error if zero entries were found.
+// The store doesn't send requests, so LinkFetch is handled like LinkFail, see SearchParameters.OnMissingLink
+func (s *Store) GetMany(ctx context.Context, parameters SearchParameters, data interface{}) error {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.Store.GetMany")
+	defer span.End()
+	parameters = offline(parameters)
+
+	response, err := s.search(parameters)
+	if err != nil {
+		addSpanError(span, trace.StatusCodeInvalidArgument, err)
+		return err
+	}
+
+	if response.Total == 0 || len(response.Items) == 0 {
+		addSpanError(span, trace.StatusCodeNotFound, ErrNoEntries)
+		return ErrNoEntries
+	}
+
+	return s.cms.parse(ctx, span, parameters, response, false, data)
+}
+
+// GetOne entry from the store. The flattened json output will be marshaled into data parameter.
+// Will return an error if there is not exactly one entry found.
+// The store doesn't send requests, so LinkFetch is handled like LinkFail, see SearchParameters.OnMissingLink
+func (s *Store) GetOne(ctx context.Context, parameters SearchParameters, data interface{}) error {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.Store.GetOne")
+	defer span.End()
+	parameters = offline(parameters)
+
+	response, err := s.search(parameters)
+	if err != nil {
+		addSpanError(span, trace.StatusCodeInvalidArgument, err)
+		return err
+	}
+
+	if response.Total == 0 || len(response.Items) == 0 {
+		addSpanError(span, trace.StatusCodeNotFound, ErrNoEntries)
+		return ErrNoEntries
+	}
+
+	if response.Total != 1 || len(response.Items) != 1 {
+		addSpanError(span, trace.StatusCodeOutOfRange, ErrMoreThanOneEntry)
+		return ErrMoreThanOneEntry
+	}
+
+	return s.cms.parse(ctx, span, parameters, response, true, data)
+}
+
+// offline returns the parameters with LinkFetch replaced with LinkFail, so that the links missing from the store
+// are not fetched from Contentful
+func offline(parameters SearchParameters) SearchParameters {
+	if parameters.missingLinks == LinkFetch {
+		return parameters.OnMissingLink(LinkFail)
+	}
+	return parameters
+}
+
+// search returns the matching entries in the same form as Contentful.search, so the references can be resolved
+// with the same logic. All the entries and assets of the store are returned as includes
+func (s *Store) search(parameters SearchParameters) (searchResults, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	response := searchResults{}
+	locale, skip, limit, err := s.parseParameters(parameters)
+	if err != nil {
+		return response, err
+	}
+
+	entries := make([]SyncEvent, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	sortEvents(entries)
+
+	for _, entry := range entries {
+		localized := entry.localizedItem(locale, s.defaultLocale)
+		if matches(parameters, localized) {
+			response.Items = append(response.Items, localized)
+		}
+		response.Includes.Entry = append(response.Includes.Entry, localized)
+	}
+	for _, asset := range s.assets {
+		response.Includes.Asset = append(response.Includes.Asset, asset.localizedItem(locale, s.defaultLocale))
+	}
+
+	response.Total = len(response.Items)
+	response.Skip = skip
+	response.Limit = limit
+	if skip > len(response.Items) {
+		skip = len(response.Items)
+	}
+	response.Items = response.Items[skip:]
+	if limit < len(response.Items) {
+		response.Items = response.Items[:limit]
+	}
+
+	return response, nil
+}
+
+// parseParameters returns the locale, skip and limit of the parameters, or an error if a parameter isn't supported
+func (s *Store) parseParameters(parameters SearchParameters) (locale string, skip, limit int, err error) {
+	locale, skip, limit = s.defaultLocale, 0, 100
+
+	for key, values := range parameters.Values {
+		switch {
+		case key == "locale":
+			locale = values[0]
+		case key == "skip":
+			skip, err = strconv.Atoi(values[0])
+		case key == "limit":
+			limit, err = strconv.Atoi(values[0])
+		case key == "content_type" || key == "sys.id" || key == "include":
+		case strings.HasPrefix(key, "fields.") && !strings.Contains(key, "["):
+		default:
+			err = fmt.Errorf("contentful: parameter %s is not supported by the store", key)
+		}
+		if err != nil {
+			return locale, skip, limit, err
+		}
+	}
+
+	return locale, skip, limit, nil
+}
+
+func matches(parameters SearchParameters, i item) bool {
+	for key, values := range parameters.Values {
+		switch {
+		case key == "content_type":
+			if i.Sys.ContentType.Sys.ID != values[0] {
+				return false
+			}
+		case key == "sys.id":
+			if i.Sys.ID != values[0] {
+				return false
+			}
+		case strings.HasPrefix(key, "fields."):
+			for _, value := range values {
+				if !fieldMatches(i.Fields[strings.TrimPrefix(key, "fields.")], value) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func fieldMatches(field interface{}, value string) bool {
+	if array, ok := field.([]interface{}); ok {
+		for _, v := range array {
+			if fieldMatches(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch t := field.(type) {
+	case string:
+		return t == value
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64) == value
+	case bool:
+		return strconv.FormatBool(t) == value
+	default:
+		return false
+	}
+}
+
+func sortEvents(events []SyncEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+}
+
+// DirectoryPersistence saves the store as JSON files to a directory: one file per entry and asset to
+// "entries" and "assets" subdirectories and the sync token to "sync_token" file
+type DirectoryPersistence struct {
+	Dir string
+}
+
+const (
+	syncTokenFile  = "sync_token"
+	entriesDir     = "entries"
+	assetsDir      = "assets"
+	jsonFileSuffix = ".json"
+)
+
+// Load the snapshot from the directory
+func (p DirectoryPersistence) Load() (StoreSnapshot, error) {
+	snapshot := StoreSnapshot{}
+
+	token, err := ioutil.ReadFile(filepath.Join(p.Dir, syncTokenFile))
+	if os.IsNotExist(err) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.SyncToken = string(token)
+
+	snapshot.Entries, err = loadEvents(filepath.Join(p.Dir, entriesDir))
+	if err != nil {
+		return snapshot, err
+	}
+
+	snapshot.Assets, err = loadEvents(filepath.Join(p.Dir, assetsDir))
+	if err != nil {
+		return snapshot, err
+	}
+
+	return snapshot, nil
+}
+
+// Save the snapshot to the directory. The sync token is written last, so a partially written snapshot
+// is never loaded with a newer sync token
+func (p DirectoryPersistence) Save(snapshot StoreSnapshot) error {
+	err := saveEvents(filepath.Join(p.Dir, entriesDir), snapshot.Entries)
+	if err != nil {
+		return err
+	}
+
+	err = saveEvents(filepath.Join(p.Dir, assetsDir), snapshot.Assets)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(p.Dir, syncTokenFile), []byte(snapshot.SyncToken))
+}
+
+func loadEvents(dir string) ([]SyncEvent, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]SyncEvent, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), jsonFileSuffix) {
+			continue
+		}
+
+		bytes, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return events, err
+		}
+
+		event := SyncEvent{}
+		err = json.Unmarshal(bytes, &event)
+		if err != nil {
+			return events, fmt.Errorf("contentful: could not parse %s: %v", file.Name(), err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// saveEvents writes a file for each event and removes the files of the events no longer in the snapshot
+func saveEvents(dir string, events []SyncEvent) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool, len(events))
+	for _, event := range events {
+		bytes, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		name := event.ID + jsonFileSuffix
+		keep[name] = true
+		err = writeFileAtomic(filepath.Join(dir, name), bytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), jsonFileSuffix) && !keep[file.Name()] {
+			err = os.Remove(filepath.Join(dir, file.Name()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	err := ioutil.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, name)
+}
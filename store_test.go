@@ -0,0 +1,261 @@
+package contentful
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "contentful-store")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		requests []string
+		server   = newSyncServer(t, &requests)
+		cms      = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		store    = NewStore(cms, "en-US", DirectoryPersistence{Dir: dir})
+		ctx      = context.Background()
+	)
+	defer server.Close()
+
+	type page struct {
+		Title    string `json:"title"`
+		Banner   Asset  `json:"banner"`
+		SubPages []page `json:"subPages"`
+		Information
+	}
+
+	t.Run("Initial sync", func(t *testing.T) {
+		err := store.Sync(ctx)
+		assert.NoError(t, err)
+
+		var p page
+		err = store.GetOne(ctx, Parameters().ByContentType("page").ByFieldValue("title", "Main page"), &p)
+		assert.NoError(t, err)
+		assert.Equal(t, "2Cbt07njicqO4wSYCQ8CeK", p.ID)
+		assert.Equal(t, "page", p.ContentType)
+		assert.Equal(t, "en-US", p.Locale)
+		assert.Equal(t, "Green", p.Banner.Title)
+		assert.Equal(t, "green.png", p.Banner.File.FileName)
+		assert.Equal(t, 1, len(p.SubPages))
+		assert.Equal(t, "Sub page", p.SubPages[0].Title)
+
+		var pages []page
+		err = store.GetMany(ctx, Parameters().ByContentType("page"), &pages)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(pages))
+
+		err = store.GetOne(ctx, Parameters().ByContentType("page"), &p)
+		assert.Equal(t, ErrMoreThanOneEntry, err)
+
+		err = store.GetMany(ctx, Parameters().ByContentType("page").Skip(1).Limit(1), &pages)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(pages))
+		assert.Equal(t, "Sub page", pages[0].Title)
+
+		err = store.GetMany(ctx, Parameters().ByContentType("article"), &pages)
+		assert.Equal(t, ErrNoEntries, err)
+	})
+
+	t.Run("Locales fall back to the default locale", func(t *testing.T) {
+		var p page
+		err := store.GetOne(ctx, Parameters().ByID("2Cbt07njicqO4wSYCQ8CeK").ByLocale("fi-FI"), &p)
+		assert.NoError(t, err)
+		assert.Equal(t, "Pääsivu", p.Title)
+		assert.Equal(t, "fi-FI", p.Locale)
+		assert.Equal(t, "Alasivu", p.SubPages[0].Title)
+		assert.Equal(t, "Green", p.Banner.Title)
+	})
+
+	t.Run("Delta sync", func(t *testing.T) {
+		requests = nil
+		err := store.Sync(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"sync_token=delta1"}, requests)
+
+		var p page
+		err = store.GetOne(ctx, Parameters().ByContentType("page"), &p)
+		assert.NoError(t, err)
+		assert.Equal(t, "New main page", p.Title)
+		assert.Equal(t, 3, p.Revision)
+		assert.Equal(t, 0, len(p.SubPages))
+
+		err = store.GetOne(ctx, Parameters().ByID("FcAxxzogmsOMcc0kac6Iu"), &p)
+		assert.Equal(t, ErrNoEntries, err)
+	})
+
+	t.Run("Content is persisted", func(t *testing.T) {
+		_, err := os.Stat(filepath.Join(dir, "entries", "FcAxxzogmsOMcc0kac6Iu.json"))
+		assert.True(t, os.IsNotExist(err))
+
+		offline := NewStore(NewWithOptions("token", "spaceID", WithBaseURL(":")), "en-US", DirectoryPersistence{Dir: dir})
+		err = offline.Sync(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, "delta2", offline.syncToken)
+
+		var p page
+		err = offline.GetOne(ctx, Parameters().ByContentType("page"), &p)
+		assert.NoError(t, err)
+		assert.Equal(t, "New main page", p.Title)
+		assert.Equal(t, "Green", p.Banner.Title)
+	})
+
+	t.Run("Unsupported parameters return an error", func(t *testing.T) {
+		var p page
+		err := store.GetOne(ctx, Parameters().ByContentType("page").ByFieldValue("title[ne]", "foo"), &p)
+		assert.Error(t, err)
+
+		params := Parameters()
+		params.Set("order", "sys.createdAt")
+		err = store.GetOne(ctx, params, &p)
+		assert.Error(t, err)
+	})
+}
+
+type snapshotPersistence struct {
+	snapshot StoreSnapshot
+}
+
+func (p *snapshotPersistence) Load() (StoreSnapshot, error) {
+	return p.snapshot, nil
+}
+
+func (p *snapshotPersistence) Save(snapshot StoreSnapshot) error {
+	p.snapshot = snapshot
+	return nil
+}
+
+func TestStore_missingLinks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("store sent a request: %s", r.URL)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var (
+		persistence = &snapshotPersistence{snapshot: StoreSnapshot{
+			SyncToken: "token",
+			Entries: []SyncEvent{{
+				Type:        SyncEntry,
+				Information: Information{ID: "article", ContentType: "article"},
+				Fields: map[string]map[string]interface{}{
+					"title": {"en-US": "Article"},
+					"author": {"en-US": map[string]interface{}{
+						"sys": map[string]interface{}{"type": "Link", "linkType": "Entry", "id": "author"},
+					}},
+				},
+			}},
+		}}
+		store = NewStore(NewWithOptions("token", "spaceID", WithBaseURL(server.URL)), "en-US", persistence)
+		ctx   = context.Background()
+	)
+	assert.NoError(t, store.Load())
+
+	var article map[string]interface{}
+	err := store.GetOne(ctx, Parameters().ByID("article").OnMissingLink(LinkFetch), &article)
+	assert.Error(t, err)
+
+	var articles []map[string]interface{}
+	err = store.GetMany(ctx, Parameters().OnMissingLink(LinkFetch), &articles)
+	assert.Error(t, err)
+
+	err = store.GetOne(ctx, Parameters().ByID("article").OnMissingLink(LinkNull), &article)
+	assert.NoError(t, err)
+	assert.Equal(t, "Article", article["title"])
+	assert.Nil(t, article["author"])
+}
+
+func TestStore_readDuringSync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requested = make(chan struct{})
+		release   = make(chan struct{})
+		server    = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requested)
+			<-release
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		persistence = &snapshotPersistence{snapshot: StoreSnapshot{
+			SyncToken: "token",
+			Entries: []SyncEvent{{
+				Type:        SyncEntry,
+				Information: Information{ID: "article", ContentType: "article"},
+				Fields:      map[string]map[string]interface{}{"title": {"en-US": "Article"}},
+			}},
+		}}
+		cms   = NewWithOptions("token", "spaceID", WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+		store = NewStore(cms, "en-US", persistence)
+		ctx   = context.Background()
+		done  = make(chan error)
+	)
+	defer server.Close()
+	assert.NoError(t, store.Load())
+
+	go func() {
+		done <- store.Sync(ctx)
+	}()
+	<-requested
+
+	// The sync request is pending, but the content can be read
+	var article map[string]interface{}
+	err := store.GetOne(ctx, Parameters().ByID("article"), &article)
+	assert.NoError(t, err)
+	assert.Equal(t, "Article", article["title"])
+
+	close(release)
+	assert.Error(t, <-done)
+	assert.Equal(t, "token", store.syncToken)
+}
+
+func TestDirectoryPersistence(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "contentful-store")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	persistence := DirectoryPersistence{Dir: filepath.Join(dir, "store")}
+
+	snapshot, err := persistence.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, StoreSnapshot{}, snapshot)
+
+	entry := SyncEvent{
+		Type:        SyncEntry,
+		Information: Information{ID: "entry", ContentType: "page", Revision: 1},
+		Fields:      map[string]map[string]interface{}{"title": {"en-US": "Title"}},
+	}
+	asset := SyncEvent{
+		Type:        SyncAsset,
+		Information: Information{ID: "asset", Revision: 2},
+		Fields:      map[string]map[string]interface{}{"title": {"en-US": "Asset"}},
+	}
+	err = persistence.Save(StoreSnapshot{SyncToken: "token", Entries: []SyncEvent{entry}, Assets: []SyncEvent{asset}})
+	assert.NoError(t, err)
+
+	snapshot, err = persistence.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, StoreSnapshot{SyncToken: "token", Entries: []SyncEvent{entry}, Assets: []SyncEvent{asset}}, snapshot)
+
+	err = persistence.Save(StoreSnapshot{SyncToken: "token2", Entries: []SyncEvent{}, Assets: []SyncEvent{asset}})
+	assert.NoError(t, err)
+
+	snapshot, err = persistence.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "token2", snapshot.SyncToken)
+	assert.Equal(t, 0, len(snapshot.Entries))
+	assert.Equal(t, 1, len(snapshot.Assets))
+}
@@ -0,0 +1,193 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// SyncEventType is the type of a SyncEvent
+type SyncEventType string
+
+const (
+	// SyncEntry is an entry which was created or updated
+	SyncEntry SyncEventType = "Entry"
+	// SyncAsset is an asset which was created or updated
+	SyncAsset SyncEventType = "Asset"
+	// SyncDeletedEntry is an entry which was deleted or unpublished
+	SyncDeletedEntry SyncEventType = "DeletedEntry"
+	// SyncDeletedAsset is an asset which was deleted or unpublished
+	SyncDeletedAsset SyncEventType = "DeletedAsset"
+)
+
+// ErrNoSyncToken is returned if the Sync API response didn't contain the next sync token
+var ErrNoSyncToken = errors.New("contentful: sync response didn't have a next sync token")
+
+// SyncEvent is a change in the space returned by the Sync API
+type SyncEvent struct {
+	Type SyncEventType
+	// Information about the entry or asset. Locale is always empty, because the Sync API returns all the locales
+	Information
+	// DeletedAt is set for deleted entries and assets
+	DeletedAt time.Time
+	// Fields of the entry or asset by field name and locale, e.g. Fields["title"]["en-US"].
+	// Empty for deleted entries and assets
+	Fields map[string]map[string]interface{}
+}
+
+// SyncResult holds the changes in the space since the previous sync
+type SyncResult struct {
+	Events []SyncEvent
+	// NextSyncToken should be persisted and given to the next call of Contentful.Sync to fetch only the changes
+	// made after this sync
+	NextSyncToken string
+}
+
+type syncResponse struct {
+	Items       []item `json:"items"`
+	NextPageURL string `json:"nextPageUrl"`
+	NextSyncURL string `json:"nextSyncUrl"`
+}
+
+// Sync fetches the changes in the space using the Sync API. If syncToken is empty, an initial sync is done,
+// which returns all the published entries and assets. Otherwise only the changes made after the sync,
+// which returned the token, are returned. All the pages of the response will be fetched.
+//
+// Note that the preview API supports only initial syncs
+func (cms *Contentful) Sync(ctx context.Context, syncToken string) (SyncResult, error) {
+	ctx, span := trace.StartSpan(ctx, "github.com/janivihervas/contentful-go.Sync")
+	defer span.End()
+
+	var (
+		result = SyncResult{}
+		query  = url.Values{}
+		pages  int64
+	)
+
+	if syncToken == "" {
+		query.Set("initial", "true")
+	} else {
+		query.Set("sync_token", syncToken)
+	}
+
+	for {
+		response := syncResponse{}
+		err := cms.get(ctx, span, cms.endpoint(ctx, "/sync"), query, &response)
+		if err != nil {
+			return result, err
+		}
+		pages++
+
+		for _, item := range response.Items {
+			result.Events = append(result.Events, newSyncEvent(item))
+		}
+
+		if response.NextPageURL != "" {
+			token, err := syncTokenFromURL(response.NextPageURL)
+			if err != nil {
+				addSpanError(span, trace.StatusCodeInternal, err)
+				return result, err
+			}
+			query = url.Values{"sync_token": []string{token}}
+			continue
+		}
+
+		token, err := syncTokenFromURL(response.NextSyncURL)
+		if err != nil {
+			addSpanError(span, trace.StatusCodeInternal, err)
+			return result, err
+		}
+		result.NextSyncToken = token
+		break
+	}
+
+	span.AddAttributes(trace.Int64Attribute("contentful.pages", pages))
+	span.AddAttributes(trace.Int64Attribute("contentful.events", int64(len(result.Events))))
+
+	return result, nil
+}
+
+// Decode marshals the fields of the given locale as json into data parameter. References are not resolved,
+// see Store for that
+func (e SyncEvent) Decode(locale string, data interface{}) error {
+	bytes, err := json.Marshal(e.localizedItem(locale, locale).Fields)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, data)
+}
+
+// localizedItem returns the event as an item, which has only the field values of the given locale.
+// If a field doesn't have a value for the locale, the value of the fallback locale is used
+func (e SyncEvent) localizedItem(locale string, fallback string) item {
+	i := item{
+		Sys: itemInfo{
+			Type:      string(e.Type),
+			ID:        e.ID,
+			Revision:  e.Revision,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+			Locale:    locale,
+			DeletedAt: e.DeletedAt,
+		},
+		Fields: make(map[string]interface{}, len(e.Fields)),
+	}
+	i.Sys.ContentType.Sys.ID = e.ContentType
+
+	for key, values := range e.Fields {
+		if value, ok := values[locale]; ok {
+			i.Fields[key] = value
+		} else if value, ok := values[fallback]; ok {
+			i.Fields[key] = value
+		}
+	}
+
+	return i
+}
+
+func newSyncEvent(i item) SyncEvent {
+	event := SyncEvent{
+		Type: SyncEventType(i.Sys.Type),
+		Information: Information{
+			ID:          i.Sys.ID,
+			ContentType: i.Sys.ContentType.Sys.ID,
+			Revision:    i.Sys.Revision,
+			CreatedAt:   i.Sys.CreatedAt,
+			UpdatedAt:   i.Sys.UpdatedAt,
+		},
+		DeletedAt: i.Sys.DeletedAt,
+		Fields:    make(map[string]map[string]interface{}, len(i.Fields)),
+	}
+
+	for key, field := range i.Fields {
+		if values, ok := field.(map[string]interface{}); ok {
+			event.Fields[key] = values
+		}
+	}
+
+	return event
+}
+
+func syncTokenFromURL(u string) (string, error) {
+	if u == "" {
+		return "", ErrNoSyncToken
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", fmt.Errorf("contentful: could not parse sync url %s: %v", u, err)
+	}
+
+	token := parsed.Query().Get("sync_token")
+	if token == "" {
+		return "", ErrNoSyncToken
+	}
+
+	return token, nil
+}
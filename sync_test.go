@@ -0,0 +1,117 @@
+package contentful
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSyncServer(t *testing.T, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/spaces/spaceID/sync", r.URL.Path)
+		*requests = append(*requests, r.URL.RawQuery)
+
+		files := map[string]string{
+			"initial=true":      "sync_initial_1.json",
+			"sync_token=page2":  "sync_initial_2.json",
+			"sync_token=delta1": "sync_delta.json",
+		}
+		file, ok := files[r.URL.RawQuery]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		bytes, err := ioutil.ReadFile("testdata/" + file)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(bytes)
+		assert.NoError(t, err)
+	}))
+}
+
+func TestContentful_Sync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests []string
+		server   = newSyncServer(t, &requests)
+		cms      = NewWithOptions("token", "spaceID", WithBaseURL(server.URL))
+		ctx      = context.Background()
+	)
+	defer server.Close()
+
+	t.Run("Initial sync follows the next page urls", func(t *testing.T) {
+		requests = nil
+		result, err := cms.Sync(ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"initial=true", "sync_token=page2"}, requests)
+		assert.Equal(t, "delta1", result.NextSyncToken)
+		assert.Equal(t, 3, len(result.Events))
+
+		main := result.Events[0]
+		assert.Equal(t, SyncEntry, main.Type)
+		assert.Equal(t, "2Cbt07njicqO4wSYCQ8CeK", main.ID)
+		assert.Equal(t, "page", main.ContentType)
+		assert.Equal(t, 2, main.Revision)
+		assert.Equal(t, "Pääsivu", main.Fields["title"]["fi-FI"])
+
+		var page struct {
+			Title string `json:"title"`
+		}
+		err = main.Decode("fi-FI", &page)
+		assert.NoError(t, err)
+		assert.Equal(t, "Pääsivu", page.Title)
+
+		asset := result.Events[2]
+		assert.Equal(t, SyncAsset, asset.Type)
+		assert.Equal(t, "", asset.ContentType)
+		var a Asset
+		err = asset.Decode("en-US", &a)
+		assert.NoError(t, err)
+		assert.Equal(t, "Green", a.Title)
+		assert.Equal(t, "green.png", a.File.FileName)
+	})
+
+	t.Run("Delta sync returns the changes", func(t *testing.T) {
+		requests = nil
+		result, err := cms.Sync(ctx, "delta1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"sync_token=delta1"}, requests)
+		assert.Equal(t, "delta2", result.NextSyncToken)
+		assert.Equal(t, 3, len(result.Events))
+
+		assert.Equal(t, SyncEntry, result.Events[0].Type)
+		assert.Equal(t, 3, result.Events[0].Revision)
+		assert.Equal(t, SyncDeletedEntry, result.Events[1].Type)
+		assert.Equal(t, "FcAxxzogmsOMcc0kac6Iu", result.Events[1].ID)
+		assert.Equal(t, time.Date(2018, 3, 1, 10, 0, 0, 0, time.UTC), result.Events[1].DeletedAt)
+		assert.Equal(t, 0, len(result.Events[1].Fields))
+		assert.Equal(t, SyncDeletedAsset, result.Events[2].Type)
+	})
+
+	t.Run("Returns an error if the request fails", func(t *testing.T) {
+		_, err := cms.Sync(ctx, "unknown")
+		assert.Error(t, err)
+	})
+}
+
+func TestSyncTokenFromURL(t *testing.T) {
+	token, err := syncTokenFromURL("https://cdn.contentful.com/spaces/space/sync?sync_token=foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", token)
+
+	_, err = syncTokenFromURL("")
+	assert.Equal(t, ErrNoSyncToken, err)
+
+	_, err = syncTokenFromURL("https://cdn.contentful.com/spaces/space/sync")
+	assert.Equal(t, ErrNoSyncToken, err)
+
+	_, err = syncTokenFromURL("%")
+	assert.Error(t, err)
+}
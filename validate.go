@@ -0,0 +1,338 @@
+package contentful
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError is returned if the search parameters are not valid, see SearchParameters.Validate
+type ValidationError struct {
+	// Parameter which is not valid, e.g. "limit" or "fields.title[ne]"
+	Parameter string
+	// Reason why the parameter is not valid
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("contentful: invalid search parameter %s: %s", e.Parameter, e.Reason)
+}
+
+// Validate the parameters before sending them to Contentful. GetMany, GetOne, GetAll and Iterate call this
+// automatically. Returns a *ValidationError if
+//   - fields are used for searching, ordering or selecting without setting the content type
+//   - limit is not between 0 and 1000
+//   - skip is negative
+//   - include is not between 0 and 10
+//   - operators on the same attribute conflict, e.g. "[in]" and "[nin]" with the same value or "[exists]=false"
+//     with other operators
+//   - "[near]" is used with order
+//   - the south-west corner of a "[within]" rectangle is north or east of the north-east corner
+func (p SearchParameters) Validate() error {
+	keys := make([]string, 0, len(p.Values))
+	for key := range p.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	err := p.validateRanges()
+	if err != nil {
+		return err
+	}
+
+	err = p.validateContentType(keys)
+	if err != nil {
+		return err
+	}
+
+	err = p.validateNear(keys)
+	if err != nil {
+		return err
+	}
+
+	operators := make(map[string]map[string][]string)
+	for _, key := range keys {
+		attribute, operator := splitOperator(key)
+		if operators[attribute] == nil {
+			operators[attribute] = make(map[string][]string)
+		}
+		operators[attribute][operator] = p.Values[key]
+	}
+
+	attributes := make([]string, 0, len(operators))
+	for attribute := range operators {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	for _, attribute := range attributes {
+		err = validateOperators(attribute, operators[attribute])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p SearchParameters) validateRanges() error {
+	ranges := []struct {
+		key      string
+		min, max int
+	}{
+		{"limit", 0, maxLimit},
+		{"skip", 0, -1},
+		{"include", 0, maxInclude},
+	}
+
+	for _, r := range ranges {
+		value := p.Get(r.key)
+		if value == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return &ValidationError{Parameter: r.key, Reason: fmt.Sprintf("%q is not an integer", value)}
+		}
+		if n < r.min || (r.max >= 0 && n > r.max) {
+			reason := fmt.Sprintf("must be at least %d, was %d", r.min, n)
+			if r.max >= 0 {
+				reason = fmt.Sprintf("must be between %d and %d, was %d", r.min, r.max, n)
+			}
+			return &ValidationError{Parameter: r.key, Reason: reason}
+		}
+	}
+
+	return nil
+}
+
+// validateContentType checks that the content type is set if fields are used for searching, ordering or selecting
+func (p SearchParameters) validateContentType(keys []string) error {
+	if p.Get("content_type") != "" {
+		return nil
+	}
+
+	for _, key := range keys {
+		if strings.HasPrefix(key, "fields.") {
+			return &ValidationError{Parameter: key, Reason: "searching by fields requires that content type is set"}
+		}
+	}
+	for _, key := range []string{"order", "select"} {
+		for _, attribute := range strings.Split(p.Get(key), ",") {
+			if strings.HasPrefix(strings.TrimPrefix(attribute, "-"), "fields.") {
+				return &ValidationError{Parameter: key, Reason: "using fields requires that content type is set"}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNear checks that "[near]" is not used with order
+func (p SearchParameters) validateNear(keys []string) error {
+	if p.Get("order") == "" {
+		return nil
+	}
+
+	for _, key := range keys {
+		if _, operator := splitOperator(key); operator == "near" {
+			return &ValidationError{Parameter: key, Reason: "near can't be used with order"}
+		}
+	}
+
+	return nil
+}
+
+// splitOperator splits "fields.title[ne]" into "fields.title" and "ne".
+// The operator of an equality search is empty
+func splitOperator(key string) (string, string) {
+	start := strings.LastIndex(key, "[")
+	if start == -1 || !strings.HasSuffix(key, "]") {
+		return key, ""
+	}
+	return key[:start], key[start+1 : len(key)-1]
+}
+
+func validateOperators(attribute string, operators map[string][]string) error {
+	validators := []func(attribute string, operators map[string][]string) error{
+		validateExists,
+		validateNotEqual,
+		validateNotIn,
+		validateWithin,
+		validateRectangle,
+		validateBounds,
+	}
+
+	for _, validate := range validators {
+		err := validate(attribute, operators)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// operatorKey joins "fields.title" and "ne" into "fields.title[ne]"
+func operatorKey(attribute, operator string) string {
+	return attribute + "[" + operator + "]"
+}
+
+// validateExists checks that "[exists]=false" is not used with other operators
+func validateExists(attribute string, operators map[string][]string) error {
+	if exists, ok := operators["exists"]; ok && len(exists) > 0 && exists[0] == "false" && len(operators) > 1 {
+		return &ValidationError{Parameter: operatorKey(attribute, "exists"), Reason: "conflicts with the other operators of " + attribute}
+	}
+	return nil
+}
+
+// validateNotEqual checks that "[ne]" doesn't exclude the value of an equality search
+func validateNotEqual(attribute string, operators map[string][]string) error {
+	notEqual, ok := operators["ne"]
+	if !ok {
+		return nil
+	}
+
+	for _, value := range operators[""] {
+		if notEqual[0] == value {
+			return &ValidationError{Parameter: operatorKey(attribute, "ne"), Reason: fmt.Sprintf("conflicts with %s=%s", attribute, value)}
+		}
+	}
+	return nil
+}
+
+// validateNotIn checks that "[in]" and "[nin]" don't have the same values
+func validateNotIn(attribute string, operators map[string][]string) error {
+	in, ok := operators["in"]
+	if !ok {
+		return nil
+	}
+	notIn, ok := operators["nin"]
+	if !ok {
+		return nil
+	}
+
+	excluded := make(map[string]bool)
+	for _, value := range strings.Split(notIn[0], ",") {
+		excluded[value] = true
+	}
+	for _, value := range strings.Split(in[0], ",") {
+		if excluded[value] {
+			return &ValidationError{
+				Parameter: operatorKey(attribute, "nin"),
+				Reason:    fmt.Sprintf("value %s is also in %s", value, operatorKey(attribute, "in")),
+			}
+		}
+	}
+	return nil
+}
+
+// validateWithin checks that "[within]" is not used with "[near]"
+func validateWithin(attribute string, operators map[string][]string) error {
+	_, within := operators["within"]
+	_, near := operators["near"]
+	if within && near {
+		return &ValidationError{Parameter: operatorKey(attribute, "within"), Reason: "can't be used with " + operatorKey(attribute, "near")}
+	}
+	return nil
+}
+
+// validateRectangle checks that the south-west corner of a "[within]" rectangle is not north or east of
+// the north-east corner
+func validateRectangle(attribute string, operators map[string][]string) error {
+	within, ok := operators["within"]
+	if !ok {
+		return nil
+	}
+
+	coordinates := strings.Split(within[0], ",")
+	if len(coordinates) != 4 {
+		return nil
+	}
+
+	var corners [4]float64
+	for i, coordinate := range coordinates {
+		value, err := strconv.ParseFloat(coordinate, 64)
+		if err != nil {
+			return nil
+		}
+		corners[i] = value
+	}
+
+	if corners[0] > corners[2] || corners[1] > corners[3] {
+		return &ValidationError{
+			Parameter: operatorKey(attribute, "within"),
+			Reason:    fmt.Sprintf("south-west corner %s,%s is not south-west of north-east corner %s,%s", coordinates[0], coordinates[1], coordinates[2], coordinates[3]),
+		}
+	}
+
+	return nil
+}
+
+// validateBounds checks that the lower bound ("[gt]" or "[gte]") is not above the upper bound ("[lt]" or "[lte]")
+func validateBounds(attribute string, operators map[string][]string) error {
+	for _, lower := range []string{"gt", "gte"} {
+		for _, upper := range []string{"lt", "lte"} {
+			lowerValues, ok := operators[lower]
+			if !ok {
+				continue
+			}
+			upperValues, ok := operators[upper]
+			if !ok {
+				continue
+			}
+
+			cmp, ok := compareValues(lowerValues[0], upperValues[0])
+			if !ok {
+				continue
+			}
+			if cmp > 0 || (cmp == 0 && (lower == "gt" || upper == "lt")) {
+				return &ValidationError{
+					Parameter: operatorKey(attribute, upper),
+					Reason: fmt.Sprintf("no value can match both %s[%s]=%s and %s[%s]=%s",
+						attribute, lower, lowerValues[0], attribute, upper, upperValues[0]),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareValues compares two numbers or dates. Returns false if the values are not comparable
+func compareValues(a, b string) (int, bool) {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		y, err := strconv.ParseFloat(b, 64)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	x, err := time.Parse(time.RFC3339Nano, a)
+	if err != nil {
+		return 0, false
+	}
+	y, err := time.Parse(time.RFC3339Nano, b)
+	if err != nil {
+		return 0, false
+	}
+	switch {
+	case x.Before(y):
+		return -1, true
+	case x.After(y):
+		return 1, true
+	default:
+		return 0, true
+	}
+}
@@ -0,0 +1,96 @@
+package contentful
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchParameters_Validate(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2018, 2, 20, 0, 0, 0, 0, time.UTC)
+	location := Location{Lat: 60.1699, Lon: 24.9384}
+	southWest := Location{Lat: 59.8236, Lon: 22.9683}
+
+	valid := []SearchParameters{
+		Parameters(),
+		{},
+		Parameters().ByContentType("page").ByFieldValue("title", "Main page"),
+		Parameters().ByID("id").ByLocale("en-US").Limit(1000).Skip(0),
+		Parameters().Limit(0),
+		Parameters().OrderBy("sys.createdAt", true).Select("sys.id"),
+		Parameters().ByContentType("page").ByFieldValue("title", "a").ByFieldNotEqual("title", "b"),
+		Parameters().ByContentType("page").ByFieldIn("tags", "a", "b").ByFieldNotIn("tags", "c"),
+		Parameters().ByContentType("page").ByFieldExists("banner", true).ByFieldNotEqual("banner", "b"),
+		Parameters().ByContentType("page").ByFieldGreaterThan("price", 1).ByFieldLessThan("price", 2),
+		Parameters().ByContentType("page").ByFieldGreaterThanOrEqual("price", 1).ByFieldLessThanOrEqual("price", 1),
+		Parameters().ByContentType("page").ByFieldAfter("published", date).ByFieldBefore("published", date.Add(time.Hour)),
+		Parameters().ByContentType("page").Near("location", location),
+		Parameters().ByContentType("page").WithinRectangle("location", southWest, location),
+		Parameters().ByContentType("page").WithinCircle("location", location, 1),
+		Parameters().LinksToEntry("id").LinksToAsset("id"),
+	}
+
+	for _, params := range valid {
+		assert.NoError(t, params.Validate(), params.Encode())
+	}
+
+	invalid := []struct {
+		params    SearchParameters
+		parameter string
+	}{
+		{Parameters().ByFieldValue("title", "Main page"), "fields.title"},
+		{Parameters().OrderBy("fields.title", true), "order"},
+		{Parameters().Select("sys.id", "fields.title"), "select"},
+		{Parameters().Limit(1001), "limit"},
+		{Parameters().Limit(-1), "limit"},
+		{SearchParameters{Values: map[string][]string{"limit": {"foo"}}}, "limit"},
+		{Parameters().Skip(-1), "skip"},
+		{SearchParameters{Values: map[string][]string{"include": {"11"}}}, "include"},
+		{Parameters().ByContentType("page").ByFieldValue("title", "a").ByFieldNotEqual("title", "a"), "fields.title[ne]"},
+		{Parameters().ByContentType("page").ByFieldIn("tags", "a", "b").ByFieldNotIn("tags", "c", "b"), "fields.tags[nin]"},
+		{Parameters().ByContentType("page").ByFieldExists("banner", false).ByFieldNotEqual("banner", "b"), "fields.banner[exists]"},
+		{Parameters().ByContentType("page").ByFieldGreaterThan("price", 2).ByFieldLessThan("price", 1), "fields.price[lt]"},
+		{Parameters().ByContentType("page").ByFieldGreaterThan("price", 1).ByFieldLessThanOrEqual("price", 1), "fields.price[lte]"},
+		{Parameters().ByContentType("page").ByFieldAfter("published", date).ByFieldBeforeOrAt("published", date), "fields.published[lte]"},
+		{Parameters().ByContentType("page").Near("location", location).OrderBy("sys.createdAt", false), "fields.location[near]"},
+		{Parameters().ByContentType("page").Near("location", location).WithinCircle("location", location, 1), "fields.location[within]"},
+		{Parameters().ByContentType("page").WithinRectangle("location", location, southWest), "fields.location[within]"},
+		{Parameters().ByContentType("page").WithinRectangle("location", Location{Lat: 59, Lon: 25}, Location{Lat: 60, Lon: 24}), "fields.location[within]"},
+	}
+
+	for _, c := range invalid {
+		err := c.params.Validate()
+		if assert.IsType(t, &ValidationError{}, err, c.params.Encode()) {
+			assert.Equal(t, c.parameter, err.(*ValidationError).Parameter)
+			assert.Contains(t, err.Error(), c.parameter)
+		}
+	}
+}
+
+func TestContentful_GetValidates(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cms    = NewWithOptions("token", "spaceID", WithBaseURL("http://localhost:0"))
+		ctx    = context.Background()
+		params = Parameters().ByFieldValue("title", "Main page")
+		result []map[string]interface{}
+	)
+
+	err := cms.GetMany(ctx, params, &result)
+	assert.IsType(t, &ValidationError{}, err)
+
+	err = cms.GetOne(ctx, params, &result)
+	assert.IsType(t, &ValidationError{}, err)
+
+	err = cms.GetAll(ctx, params, &result)
+	assert.IsType(t, &ValidationError{}, err)
+
+	it := cms.Iterate(ctx, params)
+	assert.False(t, it.Next(ctx))
+	assert.IsType(t, &ValidationError{}, it.Err())
+}
@@ -0,0 +1,90 @@
+package contentful
+
+import "fmt"
+
+// errorNotResolvable is the error Contentful returns for the links, whose targets are unpublished or deleted
+const errorNotResolvable = "notResolvable"
+
+// responseError is an error reported in the "errors" array of a successful response. Example:
+//   {
+//     "sys": {
+//       "id": "notResolvable",
+//       "type": "error"
+//     },
+//     "details": {
+//       "type": "Link",
+//       "linkType": "Entry",
+//       "id": "entryID"
+//     }
+//   }
+type responseError struct {
+	Sys struct {
+		ID string `json:"id"`
+	} `json:"sys"`
+	Details sys `json:"details"`
+}
+
+// Warning reports a problem in a response, which didn't fail the call
+type Warning struct {
+	// Reason of the warning from Contentful, e.g. "notResolvable" for a link to an unpublished or deleted entry
+	Reason string
+	// LinkType of the link, "Entry" or "Asset"
+	LinkType string
+	// ID of the link's target
+	ID string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("contentful: %s link to %s %s", w.Reason, w.LinkType, w.ID)
+}
+
+// WithWarningHandler sets a function, which is called for the warnings of the responses.
+// Links, which Contentful can't resolve because their targets are unpublished or deleted, are dropped from arrays
+// and set to null in single references. These are reported as warnings instead of failing the call
+func WithWarningHandler(handler func(Warning)) Option {
+	return func(cms *Contentful) {
+		cms.warningHandler = handler
+	}
+}
+
+// warn reports the errors of the response to the warning handler
+func (cms *Contentful) warn(errors []responseError) {
+	if cms.warningHandler == nil {
+		return
+	}
+	for _, err := range errors {
+		cms.warningHandler(Warning{
+			Reason:   err.Sys.ID,
+			LinkType: err.Details.LinkType,
+			ID:       err.Details.ID,
+		})
+	}
+}
+
+// unresolvableLinks returns the links, which Contentful couldn't resolve, keyed by "<link type>:<id>"
+func unresolvableLinks(errors []responseError) map[string]bool {
+	links := make(map[string]bool)
+	for _, err := range errors {
+		if err.Sys.ID == errorNotResolvable {
+			links[err.Details.LinkType+":"+err.Details.ID] = true
+		}
+	}
+	return links
+}
+
+// mergeErrors appends the errors of src to dst, skipping the ones already in dst
+func mergeErrors(dst []responseError, src []responseError) []responseError {
+	for _, err := range src {
+		found := false
+		for _, existing := range dst {
+			if existing == err {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, err)
+		}
+	}
+	return dst
+}
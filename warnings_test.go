@@ -0,0 +1,25 @@
+package contentful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarning(t *testing.T) {
+	warning := Warning{Reason: "notResolvable", LinkType: "Entry", ID: "entryID"}
+	assert.Equal(t, "contentful: notResolvable link to Entry entryID", warning.String())
+}
+
+func TestUnresolvableLinks(t *testing.T) {
+	var notResolvable, other responseError
+	notResolvable.Sys.ID = "notResolvable"
+	notResolvable.Details = sys{Type: "Link", LinkType: "Asset", ID: "assetID"}
+	other.Sys.ID = "other"
+	other.Details = sys{Type: "Link", LinkType: "Entry", ID: "entryID"}
+
+	assert.Equal(t, map[string]bool{"Asset:assetID": true}, unresolvableLinks([]responseError{notResolvable, other}))
+
+	merged := mergeErrors([]responseError{notResolvable}, []responseError{notResolvable, other})
+	assert.Equal(t, []responseError{notResolvable, other}, merged)
+}